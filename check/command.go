@@ -2,6 +2,7 @@ package check
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/trecnoc/nexus-resource"
 	"github.com/trecnoc/nexus-resource/models"
@@ -26,35 +27,159 @@ func (command *Command) Run(request Request) (Response, error) {
 		return Response{}, errors.New(message)
 	}
 
-	extractions := versions.GetRepositoryItemVersions(command.nexusclient, request.Source)
+	source, err := versions.ResolveFormat(command.nexusclient, request.Source)
+	if err != nil {
+		return Response{}, err
+	}
+	request.Source = source
+
+	extractions, err := versions.GetRepositoryItemVersions(command.nexusclient, request.Source)
+	if err != nil {
+		return Response{}, err
+	}
+	if request.Source.VersionIdentity == models.VersionIdentityVersion {
+		extractions = versions.UniqueVersionNumbers(extractions)
+	}
+
+	if request.Source.InitialVersion != "" {
+		initialVersion, ok := versions.ParseVersion(request.Source.InitialVersion, request.Source)
+		if !ok {
+			return Response{}, fmt.Errorf("initial_version '%s' is not a valid version", request.Source.InitialVersion)
+		}
+		extractions = fromInitialVersion(initialVersion, extractions)
+	}
 
 	if len(extractions) == 0 {
 		return nil, nil
 	}
 
-	lastVersion, matched := versions.Extract(request.Version.Path, request.Source.Regexp)
-	if !matched {
-		return latestVersion(extractions), nil
+	var response Response
+	lastVersion, matched := previousVersion(request.Source, request.Version)
+	if matched {
+		lastVersion = listedVersion(lastVersion, extractions)
+	}
+	if !matched && request.Source.InitialVersion != "" {
+		response = allVersions(request.Source, extractions)
+	} else if !matched {
+		response = latestVersion(request.Source, extractions)
+	} else if request.Source.OrderBy == models.OrderByLastModified {
+		response = uploadedVersions(request.Source, lastVersion, extractions)
+	} else {
+		response = newVersions(request.Source, lastVersion, extractions)
+	}
+
+	if request.Source.MaxVersions > 0 && len(response) > request.Source.MaxVersions {
+		response = response[len(response)-request.Source.MaxVersions:]
+	}
+
+	if request.Source.Format == models.FormatDocker {
+		err := command.addDockerDigests(request.Source, response)
+		if err != nil {
+			return Response{}, err
+		}
+	}
+
+	return response, nil
+}
+
+// previousVersion returns the Extraction of the version of the request, parsed
+// from its version number when it is the identity of the versions
+func previousVersion(source models.Source, version models.Version) (versions.Extraction, bool) {
+	if source.VersionIdentity == models.VersionIdentityVersion && version.VersionNumber != "" {
+		return versions.ParseVersion(version.VersionNumber, source)
+	}
+
+	return versions.ExtractVersion(version.Path, source)
+}
+
+// listedVersion returns the Extraction listed with the path of the previous
+// version, which may be ordered with more than its path has, e.g. the epoch of
+// an RPM, or the previous version when it isn't listed
+func listedVersion(lastVersion versions.Extraction, extractions versions.Extractions) versions.Extraction {
+	for _, extraction := range extractions {
+		if lastVersion.Path != "" && extraction.Path == lastVersion.Path {
+			return extraction
+		}
+	}
+
+	return lastVersion
+}
+
+// fromInitialVersion returns the Extractions which aren't lower than the
+// initial version
+func fromInitialVersion(initialVersion versions.Extraction, extractions versions.Extractions) versions.Extractions {
+	filtered := make(versions.Extractions, 0, len(extractions))
+	for _, extraction := range extractions {
+		if extraction.Compare(initialVersion) >= 0 {
+			filtered = append(filtered, extraction)
+		}
+	}
+
+	return filtered
+}
+
+func allVersions(source models.Source, extractions versions.Extractions) Response {
+	response := Response{}
+	for _, extraction := range extractions {
+		response = append(response, toVersion(source, extraction))
 	}
-	return newVersions(lastVersion, extractions), nil
+
+	return response
 }
 
-func latestVersion(extractions versions.Extractions) Response {
+func latestVersion(source models.Source, extractions versions.Extractions) Response {
 	lastExtraction := extractions[len(extractions)-1]
-	return []models.Version{{Path: lastExtraction.Path}}
+	return []models.Version{toVersion(source, lastExtraction)}
 }
 
-func newVersions(lastVersion versions.Extraction, extractions versions.Extractions) Response {
+func newVersions(source models.Source, lastVersion versions.Extraction, extractions versions.Extractions) Response {
 	response := Response{}
 
 	for _, extraction := range extractions {
-		if extraction.Version.Compare(lastVersion.Version) >= 0 {
-			version := models.Version{
-				Path: extraction.Path,
-			}
-			response = append(response, version)
+		if extraction.Compare(lastVersion) >= 0 {
+			response = append(response, toVersion(source, extraction))
 		}
 	}
 
 	return response
 }
+
+// uploadedVersions returns the last version and the ones uploaded after it, or
+// the latest version when it isn't found anymore
+func uploadedVersions(source models.Source, lastVersion versions.Extraction, extractions versions.Extractions) Response {
+	for i, extraction := range extractions {
+		if extraction.Path != lastVersion.Path && !sameVersionNumber(source, extraction, lastVersion) {
+			continue
+		}
+
+		response := Response{}
+		for _, uploaded := range extractions[i:] {
+			response = append(response, toVersion(source, uploaded))
+		}
+		return response
+	}
+
+	return latestVersion(source, extractions)
+}
+
+// sameVersionNumber reports whether the Extractions have the same version
+// number when it is the identity of the versions
+func sameVersionNumber(source models.Source, extraction versions.Extraction, other versions.Extraction) bool {
+	return source.VersionIdentity == models.VersionIdentityVersion && extraction.VersionNumber == other.VersionNumber
+}
+
+func toVersion(source models.Source, extraction versions.Extraction) models.Version {
+	version := models.Version{
+		Path: extraction.Path,
+	}
+
+	if source.VersionIdentity == models.VersionIdentityVersion {
+		version = models.Version{VersionNumber: extraction.VersionNumber}
+	}
+
+	if source.Format == models.FormatMaven2 {
+		version.GAV = versions.MavenGAV(source.Maven, extraction.VersionNumber)
+	}
+
+	return versions.WithContent(source, version, extraction.Sha256, extraction.LastModified)
+}
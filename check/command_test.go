@@ -1,8 +1,13 @@
 package check_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"errors"
 	"io/ioutil"
 	"os"
+	"path"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -22,6 +27,16 @@ var _ = Describe("Check Command", func() {
 			command     *Command
 		)
 
+		// components returns the components of raw files, in the group of their
+		// directory
+		components := func(names ...string) []models.RepositoryItem {
+			var items []models.RepositoryItem
+			for _, name := range names {
+				items = append(items, models.RepositoryItem{Group: "/" + path.Dir(name), Name: name})
+			}
+			return items
+		}
+
 		BeforeEach(func() {
 			var err error
 			tmpPath, err = ioutil.TempDir("", "check_command")
@@ -37,9 +52,50 @@ var _ = Describe("Check Command", func() {
 			}
 
 			nexusclient = &fakes.FakeNexusClient{}
+			nexusclient.GetRepositoryReturns(models.Repository{Name: "repository-name", Format: "raw", Type: "hosted"}, nil)
 			command = NewCommand(nexusclient)
 		})
 
+		Context("when the format is not configured", func() {
+			It("detects the format of the repository", func() {
+				nexusclient.GetRepositoryReturns(models.Repository{Name: "repository-name", Format: "helm", Type: "hosted"}, nil)
+				request.Source.Helm = models.HelmSource{Chart: "ingress-gateway"}
+				nexusclient.GetFileReturns([]byte(Fixture("helm-index.yaml")), nil)
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.GetRepositoryArgsForCall(0)).Should(Equal("repository-name"))
+				Ω(response).Should(Equal(Response{{Path: "ingress-gateway-0.10.0.tgz"}}))
+			})
+
+			It("errors on formats which are not supported", func() {
+				nexusclient.GetRepositoryReturns(models.Repository{Name: "repository-name", Format: "conan", Type: "hosted"}, nil)
+
+				_, err := command.Run(request)
+				Ω(err).Should(MatchError("repository 'repository-name' has format 'conan' which is not supported"))
+			})
+
+			It("validates the source for the detected format", func() {
+				nexusclient.GetRepositoryReturns(models.Repository{Name: "repository-name", Format: "npm", Type: "group"}, nil)
+
+				_, err := command.Run(request)
+				Ω(err).Should(MatchError("npm.package must be specified"))
+			})
+		})
+
+		Context("when the format is configured", func() {
+			It("doesn't look up the repository", func() {
+				request.Source.Format = models.FormatRaw
+				request.Source.Regexp = "a-file-(.*)"
+				request.Source.Group = "/"
+
+				_, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(nexusclient.GetRepositoryCallCount()).Should(Equal(0))
+			})
+		})
+
 		AfterEach(func() {
 			err := os.RemoveAll(tmpPath)
 			Ω(err).ShouldNot(HaveOccurred())
@@ -76,12 +132,13 @@ var _ = Describe("Check Command", func() {
 					request.Source.Group = "/files/*/sub"
 					request.Source.Regexp = "files/v(.*)/sub/abc.tgz"
 
-					nexusclient.ListFilesReturns([]string{
+					nexusclient.SearchComponentsReturns(components(
+						"files/notes.txt",
 						"files/v0.0.1/sub/abc.tgz",
 						"files/v2.33.333/sub/abc.tgz",
 						"files/v2.4.3/sub/abc.tgz",
 						"files/v3.53/sub/abc.tgz",
-					}, nil)
+					), nil)
 
 					response, err := command.Run(request)
 					Ω(err).ShouldNot(HaveOccurred())
@@ -120,12 +177,13 @@ var _ = Describe("Check Command", func() {
 						request.Source.Group = "/files/*/sub"
 						request.Source.Regexp = "files/v(.*)/sub/missing-(.*).tgz"
 
-						nexusclient.ListFilesReturns([]string{
+						nexusclient.SearchComponentsReturns(components(
+							"files/notes.txt",
 							"files/v0.0.1/sub/abc.tgz",
 							"files/v2.33.333/sub/abc.tgz",
 							"files/v2.4.3/sub/abc.tgz",
 							"files/v3.53/sub/abc.tgz",
-						}, nil)
+						), nil)
 
 						response, err := command.Run(request)
 						Ω(err).ShouldNot(HaveOccurred())
@@ -163,12 +221,13 @@ var _ = Describe("Check Command", func() {
 						request.Source.Group = "/files/*/sub"
 						request.Source.Regexp = `files/v(2\.33.*)/sub/abc.tgz`
 
-						nexusclient.ListFilesReturns([]string{
+						nexusclient.SearchComponentsReturns(components(
+							"files/notes.txt",
 							"files/v0.0.1/sub/abc.tgz",
 							"files/v2.33.333/sub/abc.tgz",
 							"files/v2.4.3/sub/abc.tgz",
 							"files/v3.53/sub/abc.tgz",
-						}, nil)
+						), nil)
 
 						response, err := command.Run(request)
 						Ω(err).ShouldNot(HaveOccurred())
@@ -180,6 +239,513 @@ var _ = Describe("Check Command", func() {
 			})
 		})
 
+		Context("when a matching path has a version which can't be parsed", func() {
+			BeforeEach(func() {
+				request.Source.Group = "/files"
+				request.Source.Regexp = "files/abc-(.*).tgz"
+
+				nexusclient.ListFilesReturns([]string{
+					"files/abc-1.0.0.tgz",
+					"files/abc-1..2.tgz",
+					"files/abc-1.1.0.tgz",
+				}, nil)
+				request.Version.Path = "files/abc-1.0.0.tgz"
+			})
+
+			It("skips it", func() {
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "files/abc-1.0.0.tgz"},
+					{Path: "files/abc-1.1.0.tgz"},
+				}))
+			})
+
+			It("errors in strict mode", func() {
+				request.Source.Strict = true
+
+				_, err := command.Run(request)
+				Ω(err).Should(MatchError("extracting versions: 1 matching path(s) have a version which can't be parsed"))
+			})
+		})
+
+		Context("when selectors are configured", func() {
+			BeforeEach(func() {
+				request.Source.Selectors = []models.Selector{
+					{Group: "/legacy/app", Regexp: "legacy/app/app_(.*).tar.gz"},
+					{Group: "/app/releases", Regexp: "app/releases/app-(.*).tgz"},
+				}
+
+				nexusclient.ListFilesStub = func(repositoryName string, group string) ([]string, error) {
+					if group == "/legacy/app" {
+						return []string{"legacy/app/app_1.0.0.tar.gz", "legacy/app/app_1.1.0.tar.gz", "legacy/app/app_2.0.0.tar.gz"}, nil
+					}
+					return []string{"app/releases/app-2.0.0.tgz", "app/releases/app-2.1.0.tgz"}, nil
+				}
+			})
+
+			It("merges the versions of the selectors", func() {
+				request.Version.Path = "legacy/app/app_1.1.0.tar.gz"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.ListFilesCallCount()).Should(Equal(2))
+				Ω(response).Should(Equal(Response{
+					{Path: "legacy/app/app_1.1.0.tar.gz"},
+					{Path: "legacy/app/app_2.0.0.tar.gz"},
+					{Path: "app/releases/app-2.1.0.tgz"},
+				}))
+			})
+		})
+
+		Context("when paths are ignored", func() {
+			BeforeEach(func() {
+				request.Source.Group = "/files/**"
+				request.Source.Regexp = "files/(?:.*/)?app-(.*).tgz"
+				request.Source.IgnoreRegexps = []string{`.*/app-.*-debug\.tgz`}
+				request.Source.IgnoreGlobs = []string{"files/tmp/**"}
+
+				nexusclient.SearchComponentsReturns(components(
+					"files/tmp/app-3.0.0.tgz",
+					"files/app-1.0.0.tgz",
+					"files/app-1.1.0.tgz",
+					"files/app-1.2.0-debug.tgz",
+				), nil)
+			})
+
+			It("excludes the paths matched by the ignore regexps and globs", func() {
+				request.Version.Path = "files/app-1.0.0.tgz"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "files/app-1.0.0.tgz"},
+					{Path: "files/app-1.1.0.tgz"},
+				}))
+			})
+		})
+
+		Context("when the versions are ordered by named groups", func() {
+			BeforeEach(func() {
+				request.Source.Regexp = `files/app-(?P<version>[^-]*)-(?P<build>\d+).tgz`
+				request.Source.SortKeys = []string{"build"}
+
+				nexusclient.ListFilesReturns([]string{
+					"files/app-0.1.0-3.tgz",
+					"files/app-1.0.0-1.tgz",
+					"files/app-1.1.0-2.tgz",
+				}, nil)
+			})
+
+			It("compares the initial version by its version number", func() {
+				request.Source.InitialVersion = "1.0.0"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "files/app-1.0.0-1.tgz"},
+					{Path: "files/app-1.1.0-2.tgz"},
+				}))
+			})
+
+			It("compares the previous version number by its version number", func() {
+				request.Source.VersionIdentity = models.VersionIdentityVersion
+				request.Version = models.Version{VersionNumber: "1.0.0"}
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{VersionNumber: "1.0.0"},
+					{VersionNumber: "1.1.0"},
+				}))
+			})
+		})
+
+		Context("when the version number is the identity of the versions", func() {
+			BeforeEach(func() {
+				request.Source.Group = "/**"
+				request.Source.Regexp = "(?:staging|release)/app/app-(.*).tgz"
+				request.Source.VersionIdentity = models.VersionIdentityVersion
+
+				nexusclient.SearchComponentsReturns(components(
+					"staging/app/app-1.1.0.tgz",
+					"staging/app/app-1.2.0.tgz",
+					"release/app/app-1.0.0.tgz",
+					"release/app/app-1.1.0.tgz",
+				), nil)
+			})
+
+			It("emits the version numbers once, whatever their paths", func() {
+				request.Version = models.Version{VersionNumber: "1.0.0"}
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{VersionNumber: "1.0.0"},
+					{VersionNumber: "1.1.0"},
+					{VersionNumber: "1.2.0"},
+				}))
+			})
+
+			It("compares the version numbers without their version prefix", func() {
+				request.Source.Regexp = "(?:staging|release)/app/app-(v?.*).tgz"
+				request.Source.VersionPrefix = "v"
+				request.Source.VersionScheme = models.VersionSchemeSemver
+				nexusclient.SearchComponentsReturns(components(
+					"release/app/app-v1.0.0.tgz",
+					"release/app/app-v1.1.0.tgz",
+					"staging/app/app-v1.2.0.tgz",
+				), nil)
+				request.Version = models.Version{VersionNumber: "1.1.0"}
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{VersionNumber: "1.1.0"},
+					{VersionNumber: "1.2.0"},
+				}))
+			})
+		})
+
+		Context("when uploads must be settled", func() {
+			asset := func(name string, age time.Duration, sha256 string) models.RepositoryItem {
+				return models.RepositoryItem{
+					Name: name,
+					Assets: []models.RepositoryItemAsset{{
+						Path:         name,
+						Checksum:     models.RepositoryItemAssetsChecksum{Sha256: sha256},
+						LastModified: time.Now().Add(-age),
+					}},
+				}
+			}
+
+			BeforeEach(func() {
+				request.Source.Group = "/files"
+				request.Source.Regexp = "files/app-(.*).tgz"
+				request.Source.MinAge = "10m"
+				request.Source.MaxAge = "720h"
+				request.Source.RequireChecksum = true
+
+				nexusclient.SearchComponentsReturns([]models.RepositoryItem{
+					asset("files/app-0.9.0.tgz", 1000*time.Hour, "aaa"),
+					asset("files/app-1.0.0.tgz", 48*time.Hour, "bbb"),
+					asset("files/app-1.1.0.tgz", time.Hour, ""),
+					asset("files/app-1.2.0.tgz", 2*time.Minute, "ccc"),
+				}, nil)
+			})
+
+			It("skips the assets uploaded outside the window or without checksum", func() {
+				request.Version.Path = "files/app-0.9.0.tgz"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.ListFilesCallCount()).Should(Equal(0))
+				Ω(response).Should(Equal(Response{{Path: "files/app-1.0.0.tgz"}}))
+			})
+		})
+
+		Context("when a version constraint is configured", func() {
+			BeforeEach(func() {
+				request.Source.Group = "/files"
+				request.Source.Regexp = "files/abc-(.*).tgz"
+				request.Source.VersionConstraint = ">=2.3.0 <3.0.0"
+
+				nexusclient.ListFilesReturns([]string{
+					"files/abc-2.2.0.tgz",
+					"files/abc-2.3.0.tgz",
+					"files/abc-2.10.1.tgz",
+					"files/abc-3.0.0.tgz",
+				}, nil)
+			})
+
+			It("includes the latest version satisfying the constraint", func() {
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{{Path: "files/abc-2.10.1.tgz"}}))
+			})
+
+			It("includes the versions satisfying the constraint from the previous one", func() {
+				request.Version.Path = "files/abc-2.3.0.tgz"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "files/abc-2.3.0.tgz"},
+					{Path: "files/abc-2.10.1.tgz"},
+				}))
+			})
+		})
+
+		Context("when the version history is limited", func() {
+			BeforeEach(func() {
+				request.Source.Group = "/builds"
+				request.Source.Regexp = "builds/app-(.*).zip"
+
+				nexusclient.ListFilesReturns([]string{
+					"builds/app-1.0.0.zip",
+					"builds/app-1.1.0.zip",
+					"builds/app-1.2.0.zip",
+					"builds/app-1.3.0.zip",
+					"builds/app-1.4.0.zip",
+				}, nil)
+			})
+
+			It("includes all the versions from the initial version on the first check", func() {
+				request.Source.InitialVersion = "1.2.0"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "builds/app-1.2.0.zip"},
+					{Path: "builds/app-1.3.0.zip"},
+					{Path: "builds/app-1.4.0.zip"},
+				}))
+			})
+
+			It("doesn't include versions lower than the initial version", func() {
+				request.Source.InitialVersion = "1.2.0"
+				request.Version.Path = "builds/app-1.0.0.zip"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "builds/app-1.2.0.zip"},
+					{Path: "builds/app-1.3.0.zip"},
+					{Path: "builds/app-1.4.0.zip"},
+				}))
+			})
+
+			It("includes at most max_versions of the newest versions", func() {
+				request.Source.MaxVersions = 2
+				request.Version.Path = "builds/app-1.0.0.zip"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "builds/app-1.3.0.zip"},
+					{Path: "builds/app-1.4.0.zip"},
+				}))
+			})
+
+			It("orders the initial version with the version scheme", func() {
+				request.Source.VersionScheme = models.VersionSchemeSemver
+				request.Source.VersionPrefix = "v"
+				request.Source.InitialVersion = "v1.3.0"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "builds/app-1.3.0.zip"},
+					{Path: "builds/app-1.4.0.zip"},
+				}))
+			})
+
+			It("errors when the initial version isn't valid for the version scheme", func() {
+				request.Source.VersionScheme = models.VersionSchemeSemver
+				request.Source.InitialVersion = "1.3"
+
+				_, err := command.Run(request)
+				Ω(err).Should(MatchError("initial_version '1.3' is not a valid version"))
+			})
+		})
+
+		Context("when ordering by last modification", func() {
+			uploaded := func(name string, lastModified string) models.RepositoryItem {
+				timestamp, err := time.Parse(time.RFC3339, lastModified)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				return models.RepositoryItem{
+					Name:   name,
+					Assets: []models.RepositoryItemAsset{{Path: name, LastModified: timestamp}},
+				}
+			}
+
+			BeforeEach(func() {
+				request.Source.Group = "/dumps"
+				request.Source.Regexp = "dumps/dump-(.*).sql.gz"
+				request.Source.OrderBy = models.OrderByLastModified
+
+				nexusclient.SearchComponentsReturns([]models.RepositoryItem{
+					uploaded("dumps/dump-1.3.0.sql.gz", "2026-10-15T03:00:00Z"),
+					uploaded("dumps/dump-1.1.0.sql.gz", "2026-10-17T03:00:00Z"),
+					uploaded("dumps/dump-1.2.0.sql.gz", "2026-10-16T03:00:00Z"),
+					uploaded("dumps/notes.txt", "2026-10-18T03:00:00Z"),
+				}, nil)
+			})
+
+			It("searches the assets of the group", func() {
+				_, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.ListFilesCallCount()).Should(Equal(0))
+				Ω(nexusclient.SearchComponentsCallCount()).Should(Equal(1))
+				repositoryName, parameters := nexusclient.SearchComponentsArgsForCall(0)
+				Ω(repositoryName).Should(Equal("repository-name"))
+				Ω(parameters).Should(Equal(map[string]string{"group": "/dumps"}))
+			})
+
+			It("includes the most recently uploaded version", func() {
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{{Path: "dumps/dump-1.1.0.sql.gz"}}))
+			})
+
+			It("includes the versions uploaded after the previous one, in upload order", func() {
+				request.Version.Path = "dumps/dump-1.3.0.sql.gz"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "dumps/dump-1.3.0.sql.gz"},
+					{Path: "dumps/dump-1.2.0.sql.gz"},
+					{Path: "dumps/dump-1.1.0.sql.gz"},
+				}))
+			})
+
+			It("breaks ties with the version", func() {
+				nexusclient.SearchComponentsReturns([]models.RepositoryItem{
+					uploaded("dumps/dump-1.10.0.sql.gz", "2026-10-17T03:00:00Z"),
+					uploaded("dumps/dump-1.9.0.sql.gz", "2026-10-17T03:00:00Z"),
+				}, nil)
+				request.Version.Path = "dumps/dump-1.9.0.sql.gz"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "dumps/dump-1.9.0.sql.gz"},
+					{Path: "dumps/dump-1.10.0.sql.gz"},
+				}))
+			})
+
+			It("includes the latest version when the previous one is gone", func() {
+				request.Version.Path = "dumps/dump-1.0.0.sql.gz"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{{Path: "dumps/dump-1.1.0.sql.gz"}}))
+			})
+		})
+
+		Context("when redeploys are tracked", func() {
+			BeforeEach(func() {
+				request.Source.Group = "/files"
+				request.Source.Regexp = "files/tool-(.*).tgz"
+				request.Source.TrackRedeploys = models.TrackRedeploysSha256
+
+				nexusclient.SearchComponentsReturns([]models.RepositoryItem{
+					{
+						Name: "files/tool-1.3.0.tgz",
+						Assets: []models.RepositoryItemAsset{{
+							Checksum:     models.RepositoryItemAssetsChecksum{Sha256: "abc123"},
+							LastModified: time.Date(2026, 10, 15, 3, 0, 0, 0, time.UTC),
+						}},
+					},
+					{
+						Name: "files/tool-1.4.0.tgz",
+						Assets: []models.RepositoryItemAsset{{
+							Checksum:     models.RepositoryItemAssetsChecksum{Sha256: "def456"},
+							LastModified: time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC),
+						}},
+					},
+				}, nil)
+			})
+
+			It("includes the sha256 of the asset in the version", func() {
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{{Path: "files/tool-1.4.0.tgz", Sha256: "def456"}}))
+			})
+
+			It("includes the redeployed previous version as a new version", func() {
+				request.Version = models.Version{Path: "files/tool-1.4.0.tgz", Sha256: "0123"}
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{{Path: "files/tool-1.4.0.tgz", Sha256: "def456"}}))
+			})
+
+			It("includes the last modification of the asset in the version", func() {
+				request.Source.TrackRedeploys = models.TrackRedeploysLastModified
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{{Path: "files/tool-1.4.0.tgz", LastModified: "2026-10-17T03:00:00Z"}}))
+			})
+		})
+
+		Context("when the semver version scheme is configured", func() {
+			BeforeEach(func() {
+				request.Source.Group = "/files"
+				request.Source.Regexp = "files/abc-(.*).tgz"
+				request.Source.VersionScheme = models.VersionSchemeSemver
+
+				nexusclient.ListFilesReturns([]string{
+					"files/abc-1.0.0.tgz",
+					"files/abc-1.1.0-beta.2.tgz",
+					"files/abc-1.1.0-beta.11.tgz",
+					"files/abc-1.1.0-rc.1.tgz",
+					"files/abc-1.1.tgz",
+					"files/abc-latest.tgz",
+				}, nil)
+				request.Version.Path = "files/abc-1.0.0.tgz"
+			})
+
+			It("skips invalid versions and orders by semver precedence", func() {
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "files/abc-1.0.0.tgz"},
+					{Path: "files/abc-1.1.0-beta.2.tgz"},
+					{Path: "files/abc-1.1.0-beta.11.tgz"},
+					{Path: "files/abc-1.1.0-rc.1.tgz"},
+				}))
+			})
+
+			It("excludes pre-releases", func() {
+				request.Source.PreRelease = models.PreReleaseExclude
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{{Path: "files/abc-1.0.0.tgz"}}))
+			})
+
+			It("keeps only the pre-releases with the listed identifiers", func() {
+				request.Source.PreRelease = models.PreReleaseOnly
+				request.Source.PreReleaseIdentifiers = []string{"rc"}
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "files/abc-1.0.0.tgz"},
+					{Path: "files/abc-1.1.0-rc.1.tgz"},
+				}))
+			})
+		})
+
 		Context("when there is a previous version", func() {
 			Context("when using regex that matches the provided version", func() {
 				Context("when a non-glob group is used", func() {
@@ -218,12 +784,13 @@ var _ = Describe("Check Command", func() {
 						request.Source.Group = "/files/v*/sub"
 						request.Source.Regexp = "files/v(.*)/sub/abc.tgz"
 
-						nexusclient.ListFilesReturns([]string{
+						nexusclient.SearchComponentsReturns(components(
+							"files/notes.txt",
 							"files/v0.0.1/sub/abc.tgz",
 							"files/v2.33.333/sub/abc.tgz",
 							"files/v2.4.3/sub/abc.tgz",
 							"files/v3.53/sub/abc.tgz",
-						}, nil)
+						), nil)
 
 						response, err := command.Run(request)
 						Ω(err).ShouldNot(HaveOccurred())
@@ -244,5 +811,476 @@ var _ = Describe("Check Command", func() {
 				})
 			})
 		})
+
+		Context("when the format is maven2", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatMaven2
+				request.Source.Maven = models.MavenSource{
+					GroupID:    "com.example",
+					ArtifactID: "app",
+					Version:    "1.2.0-SNAPSHOT",
+				}
+
+				nexusclient.GetFileReturns([]byte(Fixture("snapshot-maven-metadata.xml")), nil)
+			})
+
+			It("reads the version level metadata", func() {
+				_, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.GetFileCallCount()).Should(Equal(1))
+				repositoryName, name := nexusclient.GetFileArgsForCall(0)
+				Ω(repositoryName).Should(Equal("repository-name"))
+				Ω(name).Should(Equal("com/example/app/1.2.0-SNAPSHOT/maven-metadata.xml"))
+			})
+
+			It("includes the latest timestamped build when there is no previous version", func() {
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(ConsistOf(
+					models.Version{
+						Path: "com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261002.080000-8.jar",
+						GAV:  "com.example:app:1.2.0-20261002.080000-8",
+					},
+				))
+			})
+
+			It("includes every timestamped build from the previous one", func() {
+				request.Version.Path = "com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261001.123456-7.jar"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261001.123456-7.jar", GAV: "com.example:app:1.2.0-20261001.123456-7"},
+					{Path: "com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261002.080000-8.jar", GAV: "com.example:app:1.2.0-20261002.080000-8"},
+				}))
+			})
+
+			It("includes the timestamped builds from the initial version", func() {
+				request.Source.InitialVersion = "1.2.0-20261001.200000-7"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261002.080000-8.jar", GAV: "com.example:app:1.2.0-20261002.080000-8"},
+				}))
+			})
+
+			It("errors when the initial version isn't a timestamped build", func() {
+				request.Source.InitialVersion = "1.2.0"
+
+				_, err := command.Run(request)
+				Ω(err).Should(MatchError("initial_version '1.2.0' is not a valid version"))
+			})
+
+			It("only considers the configured classifier", func() {
+				request.Source.Maven.Classifier = "sources"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(ConsistOf(
+					models.Version{
+						Path: "com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261001.123456-7-sources.jar",
+						GAV:  "com.example:app:1.2.0-20261001.123456-7",
+					},
+				))
+			})
+		})
+
+		Context("when the format is npm", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatNpm
+				request.Source.Npm = models.NpmSource{Package: "@frontend/widgets"}
+
+				nexusclient.GetFileReturns([]byte(Fixture("npm-package.json")), nil)
+			})
+
+			It("reads the package document", func() {
+				_, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, name := nexusclient.GetFileArgsForCall(0)
+				Ω(name).Should(Equal("@frontend/widgets"))
+			})
+
+			It("includes all versions from the previous one", func() {
+				request.Version.Path = "@frontend/widgets/-/widgets-2.1.0.tgz"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "@frontend/widgets/-/widgets-2.1.0.tgz"},
+					{Path: "@frontend/widgets/-/widgets-2.10.0.tgz"},
+					{Path: "@frontend/widgets/-/widgets-3.0.0-beta.1.tgz"},
+				}))
+			})
+
+			It("errors when the package document can't be read", func() {
+				nexusclient.GetFileReturns(nil, errors.New("not found"))
+
+				_, err := command.Run(request)
+				Ω(err).Should(MatchError("reading npm package: not found"))
+			})
+
+			It("only follows the configured dist-tag", func() {
+				request.Source.Npm.DistTag = "latest"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "@frontend/widgets/-/widgets-2.1.0.tgz"},
+				}))
+			})
+		})
+
+		Context("when the format is pypi", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatPypi
+				request.Source.Pypi = models.PypiSource{Package: "Friendly_Bard"}
+
+				nexusclient.GetFileReturns([]byte(Fixture("pypi-simple-index.html")), nil)
+			})
+
+			It("reads the simple index of the normalized package name", func() {
+				_, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, name := nexusclient.GetFileArgsForCall(0)
+				Ω(name).Should(Equal("simple/friendly-bard/"))
+			})
+
+			It("orders the versions as per PEP 440", func() {
+				request.Version.Path = "packages/friendly-bard/1.0.0/friendly-bard-1.0.0.tar.gz"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "packages/friendly-bard/1.0.0/friendly_bard-1.0.0-py3-none-any.whl"},
+					{Path: "packages/friendly-bard/1.1.0.dev1/friendly-bard-1.1.0.dev1.tar.gz"},
+					{Path: "packages/friendly-bard/1.1.0rc1/friendly-bard-1.1.0rc1.tar.gz"},
+					{Path: "packages/friendly-bard/1.1.0/friendly_bard-1.1.0-cp311-cp311-manylinux2014_x86_64.whl"},
+				}))
+			})
+
+			It("picks the wheel of the configured platform and python tag", func() {
+				request.Source.Pypi.PythonTag = "cp311"
+				request.Source.Pypi.Platform = "win_amd64"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(ConsistOf(
+					models.Version{Path: "packages/friendly-bard/1.1.0/friendly_bard-1.1.0-cp311-cp311-win_amd64.whl"},
+				))
+			})
+
+			It("falls back to the sdist when no wheel matches", func() {
+				request.Source.Pypi.Platform = "macosx_11_0_arm64"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(ConsistOf(
+					models.Version{Path: "packages/friendly-bard/1.1.0/friendly-bard-1.1.0.tar.gz"},
+				))
+			})
+		})
+
+		Context("when the format is helm", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatHelm
+				request.Source.Repository = "helm-hosted"
+				request.Source.Helm = models.HelmSource{Chart: "ingress-gateway"}
+
+				nexusclient.GetFileReturns([]byte(Fixture("helm-index.yaml")), nil)
+			})
+
+			It("reads the index of the repository", func() {
+				_, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				repositoryName, name := nexusclient.GetFileArgsForCall(0)
+				Ω(repositoryName).Should(Equal("helm-hosted"))
+				Ω(name).Should(Equal("index.yaml"))
+			})
+
+			It("includes the chart versions from the previous one in semver order", func() {
+				request.Version.Path = "ingress-gateway-0.3.0.tgz"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "ingress-gateway-0.3.0.tgz"},
+					{Path: "ingress-gateway-0.4.1.tgz"},
+					{Path: "ingress-gateway-0.10.0.tgz"},
+				}))
+			})
+
+			It("includes the chart versions from the initial version in semver order", func() {
+				request.Source.InitialVersion = "0.4.0"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "ingress-gateway-0.4.1.tgz"},
+					{Path: "ingress-gateway-0.10.0.tgz"},
+				}))
+			})
+
+			It("filters the chart versions on their appVersion", func() {
+				request.Source.Helm.AppVersion = `2\..*`
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "ingress-gateway-0.4.1.tgz"},
+				}))
+			})
+		})
+
+		Context("when the format is nuget", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatNuGet
+				request.Source.Repository = "nuget-hosted"
+				request.Source.NuGet = models.NuGetSource{Package: "Contoso.Billing"}
+
+				nexusclient.GetFileReturns([]byte(Fixture("nuget-index.json")), nil)
+			})
+
+			It("reads the flat container index of the package", func() {
+				_, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				repositoryName, name := nexusclient.GetFileArgsForCall(0)
+				Ω(repositoryName).Should(Equal("nuget-hosted"))
+				Ω(name).Should(Equal("v3-flatcontainer/contoso.billing/index.json"))
+			})
+
+			It("includes the versions from the previous one in NuGet order", func() {
+				request.Version.Path = "v3-flatcontainer/contoso.billing/1.9.0.1/contoso.billing.1.9.0.1.nupkg"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "v3-flatcontainer/contoso.billing/1.9.0.1/contoso.billing.1.9.0.1.nupkg"},
+					{Path: "v3-flatcontainer/contoso.billing/1.10.0-beta.2/contoso.billing.1.10.0-beta.2.nupkg"},
+					{Path: "v3-flatcontainer/contoso.billing/1.10.0-beta.10/contoso.billing.1.10.0-beta.10.nupkg"},
+					{Path: "v3-flatcontainer/contoso.billing/1.10.0/contoso.billing.1.10.0.nupkg"},
+				}))
+			})
+		})
+
+		Context("when the format is go", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatGo
+				request.Source.Repository = "go-proxy"
+				request.Source.Go = models.GoSource{Module: "github.com/Contoso/billing"}
+
+				nexusclient.GetFileStub = func(repositoryName string, name string) ([]byte, error) {
+					switch name {
+					case "github.com/!contoso/billing/@v/list":
+						return []byte("v1.2.0\nv1.10.0\nv1.9.0\n"), nil
+					case "github.com/!contoso/billing/@latest":
+						return []byte(`{"Version":"v1.10.1-0.20261001123456-abcdef123456","Time":"2026-10-01T12:34:56Z"}`), nil
+					}
+					return nil, errors.New("not found")
+				}
+			})
+
+			It("includes the tagged versions from the previous one in semver order", func() {
+				request.Version.Path = "github.com/!contoso/billing/@v/v1.9.0.zip"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "github.com/!contoso/billing/@v/v1.9.0.zip"},
+					{Path: "github.com/!contoso/billing/@v/v1.10.0.zip"},
+				}))
+				Ω(nexusclient.GetFileCallCount()).Should(Equal(1))
+			})
+
+			It("includes the latest pseudo-version when enabled", func() {
+				request.Source.Go.PseudoVersions = true
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "github.com/!contoso/billing/@v/v1.10.1-0.20261001123456-abcdef123456.zip"},
+				}))
+			})
+		})
+
+		Context("when the format is apt", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatApt
+				request.Source.Repository = "apt-hosted"
+				request.Source.Apt = models.AptSource{Package: "billing-agent", Distribution: "jammy"}
+
+				nexusclient.GetFileReturns([]byte(Fixture("apt-packages")), nil)
+			})
+
+			It("reads the Packages index of the distribution", func() {
+				_, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				repositoryName, name := nexusclient.GetFileArgsForCall(0)
+				Ω(repositoryName).Should(Equal("apt-hosted"))
+				Ω(name).Should(Equal("dists/jammy/main/binary-amd64/Packages"))
+			})
+
+			It("includes the versions from the previous one in dpkg order", func() {
+				request.Version.Path = "pool/b/billing-agent/billing-agent_1.2.0~rc1-1_amd64.deb"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "pool/b/billing-agent/billing-agent_1.2.0~rc1-1_amd64.deb"},
+					{Path: "pool/b/billing-agent/billing-agent_1.2.0-1_amd64.deb"},
+					{Path: "pool/b/billing-agent/billing-agent_1.10.0-1_amd64.deb"},
+				}))
+			})
+		})
+
+		Context("when the format is yum", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatYum
+				request.Source.Repository = "yum-hosted"
+				request.Source.Yum = models.YumSource{Package: "billing-agent", Architecture: "x86_64", Directory: "/el9/"}
+
+				var primary bytes.Buffer
+				gzipWriter := gzip.NewWriter(&primary)
+				_, err := gzipWriter.Write([]byte(Fixture("yum-primary.xml")))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(gzipWriter.Close()).Should(Succeed())
+
+				nexusclient.GetFileStub = func(repositoryName string, name string) ([]byte, error) {
+					switch name {
+					case "el9/repodata/repomd.xml":
+						return []byte(Fixture("yum-repomd.xml")), nil
+					case "el9/repodata/3c1f-primary.xml.gz":
+						return primary.Bytes(), nil
+					}
+					return nil, errors.New("not found")
+				}
+			})
+
+			It("includes the versions of the architecture from the previous one in rpm order", func() {
+				request.Version.Path = "el9/billing-agent-1.2.0-1.el9.x86_64.rpm"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "el9/billing-agent-1.2.0-1.el9.x86_64.rpm"},
+					{Path: "el9/billing-agent-1.10.0-1.el9.x86_64.rpm"},
+					{Path: "el9/billing-agent-0.9.0-1.el9.x86_64.rpm"},
+				}))
+			})
+
+			It("orders the previous version by the epoch of the metadata", func() {
+				request.Version.Path = "el9/billing-agent-0.9.0-1.el9.x86_64.rpm"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{{Path: "el9/billing-agent-0.9.0-1.el9.x86_64.rpm"}}))
+			})
+		})
+
+		Context("when the format is docker", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatDocker
+				request.Source.Repository = "docker-hosted"
+				request.Source.Regexp = `v(\d+\.\d+\.\d+)`
+				request.Source.Docker = models.DockerSource{Image: "team/api"}
+
+				nexusclient.ListDockerTagsReturns([]string{"latest", "v1.10.0", "v1.2.0", "v1.9.1"}, nil)
+				nexusclient.GetDockerManifestStub = func(registryURL string, image string, reference string) (models.DockerDescriptor, []byte, error) {
+					return models.DockerDescriptor{Digest: "sha256:" + reference}, nil, nil
+				}
+			})
+
+			It("lists the tags through the registry API of the repository", func() {
+				_, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				registryURL, image := nexusclient.ListDockerTagsArgsForCall(0)
+				Ω(registryURL).Should(Equal("http://nexus-url.com/repository/docker-hosted"))
+				Ω(image).Should(Equal("team/api"))
+			})
+
+			It("includes the tags from the previous one with their digest", func() {
+				request.Version.Path = "v2/team/api/manifests/v1.9.1"
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response).Should(Equal(Response{
+					{Path: "v2/team/api/manifests/v1.9.1", Digest: "sha256:v1.9.1"},
+					{Path: "v2/team/api/manifests/v1.10.0", Digest: "sha256:v1.10.0"},
+				}))
+			})
+
+			It("fetches the manifest of every tag", func() {
+				request.Version.Path = "v2/team/api/manifests/v1.2.0"
+
+				_, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				var references []string
+				for i := 0; i < nexusclient.GetDockerManifestCallCount(); i++ {
+					_, _, reference := nexusclient.GetDockerManifestArgsForCall(i)
+					references = append(references, reference)
+				}
+				Ω(references).Should(ConsistOf("v1.2.0", "v1.9.1", "v1.10.0"))
+			})
+
+			It("returns the error of a manifest", func() {
+				request.Version.Path = "v2/team/api/manifests/v1.2.0"
+				nexusclient.GetDockerManifestStub = func(registryURL string, image string, reference string) (models.DockerDescriptor, []byte, error) {
+					if reference == "v1.9.1" {
+						return models.DockerDescriptor{}, nil, errors.New("manifest unknown")
+					}
+					return models.DockerDescriptor{Digest: "sha256:" + reference}, nil, nil
+				}
+
+				_, err := command.Run(request)
+				Ω(err).Should(MatchError("manifest unknown"))
+			})
+
+			It("searches the tags with the search API when configured", func() {
+				request.Source.Docker.SearchAPI = true
+				nexusclient.SearchComponentsReturns([]models.RepositoryItem{
+					{Name: "team/api", Version: "v1.2.0"},
+					{Name: "team/web", Version: "v3.0.0"},
+				}, nil)
+
+				response, err := command.Run(request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.ListDockerTagsCallCount()).Should(Equal(0))
+				_, params := nexusclient.SearchComponentsArgsForCall(0)
+				Ω(params).Should(HaveKeyWithValue("format", "docker"))
+				Ω(params).Should(HaveKeyWithValue("name", "team/api"))
+
+				Ω(response).Should(Equal(Response{
+					{Path: "v2/team/api/manifests/v1.2.0", Digest: "sha256:v1.2.0"},
+				}))
+			})
+		})
 	})
 })
@@ -0,0 +1,23 @@
+package check
+
+import (
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// addDockerDigests adds the manifest digest of each tag to the versions, so a
+// tag pushed again is a new version. The manifests are fetched concurrently
+func (command *Command) addDockerDigests(source models.Source, response Response) error {
+	return utils.ForEachConcurrently(len(response), func(i int) error {
+		tag, _ := versions.DockerTag(response[i].Path, source.Docker.Image)
+
+		descriptor, _, err := command.nexusclient.GetDockerManifest(source.DockerRegistryURL(), source.Docker.Image, tag)
+		if err != nil {
+			return err
+		}
+
+		response[i].Digest = descriptor.Digest
+		return nil
+	})
+}
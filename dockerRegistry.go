@@ -0,0 +1,359 @@
+package nexusresource
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/trecnoc/nexus-resource/models"
+)
+
+// Media types accepted when fetching a manifest from a Docker registry
+var dockerManifestMediaTypes = []string{
+	models.OCIImageIndexMediaType,
+	models.DockerManifestListMediaType,
+	models.OCIImageManifestMediaType,
+	models.DockerManifestMediaType,
+}
+
+// matches the parameters of a WWW-Authenticate challenge
+var challengeParameterPattern = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// matches the next page of a Link header
+var nextLinkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Blobs larger than this are uploaded in chunks of this size
+var dockerBlobChunkSize int64 = 32 * 1024 * 1024
+
+func (client *nexusclient) ListDockerTags(registryURL string, image string) ([]string, error) {
+	client.logger.LogSimpleMessageAndSay("Listing tags for image '%s' of registry '%s'", image, registryURL)
+	tags := []string{}
+
+	requestURL := strings.TrimSuffix(registryURL, "/") + "/v2/" + image + "/tags/list"
+	for requestURL != "" {
+		resp, err := client.doRegistryRequest(http.MethodGet, registryURL, image, "pull", requestURL, nil, nil)
+		if err != nil {
+			return tags, err
+		}
+
+		var tagList models.DockerTagList
+		err = json.NewDecoder(resp.Body).Decode(&tagList)
+		resp.Body.Close()
+		if err != nil {
+			return tags, err
+		}
+		tags = append(tags, tagList.Tags...)
+
+		requestURL = ""
+		if matches := nextLinkPattern.FindStringSubmatch(resp.Header.Get("Link")); matches != nil {
+			next, err := resp.Request.URL.Parse(matches[1])
+			if err != nil {
+				return tags, err
+			}
+			requestURL = next.String()
+		}
+	}
+
+	return tags, nil
+}
+
+func (client *nexusclient) GetDockerManifest(registryURL string, image string, reference string) (models.DockerDescriptor, []byte, error) {
+	client.logger.LogSimpleMessage("In GetDockerManifest for image '%s' and reference '%s'", image, reference)
+	var descriptor models.DockerDescriptor
+
+	header := http.Header{}
+	header.Set("Accept", strings.Join(dockerManifestMediaTypes, ", "))
+
+	requestURL := strings.TrimSuffix(registryURL, "/") + "/v2/" + image + "/manifests/" + reference
+	resp, err := client.doRegistryRequest(http.MethodGet, registryURL, image, "pull", requestURL, header, nil)
+	if err != nil {
+		return descriptor, nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return descriptor, nil, err
+	}
+
+	descriptor.MediaType = strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	descriptor.Digest = resp.Header.Get("Docker-Content-Digest")
+	descriptor.Size = int64(len(content))
+	if descriptor.Digest == "" {
+		descriptor.Digest = models.DockerDigest(content)
+	}
+
+	return descriptor, content, nil
+}
+
+func (client *nexusclient) DownloadDockerBlob(registryURL string, image string, digest string, localPath string) error {
+	client.logger.LogSimpleMessage("In DownloadDockerBlob for image '%s' and digest '%s'", image, digest)
+
+	requestURL := strings.TrimSuffix(registryURL, "/") + "/v2/" + image + "/blobs/" + digest
+	resp, err := client.doRegistryRequest(http.MethodGet, registryURL, image, "pull", requestURL, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	localFile, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	defer localFile.Close()
+
+	_, err = io.Copy(localFile, resp.Body)
+	return err
+}
+
+func (client *nexusclient) DockerBlobExists(registryURL string, image string, digest string) (bool, error) {
+	client.logger.LogSimpleMessage("In DockerBlobExists for image '%s' and digest '%s'", image, digest)
+
+	requestURL := strings.TrimSuffix(registryURL, "/") + "/v2/" + image + "/blobs/" + digest
+	resp, err := client.doRegistryRequest(http.MethodHead, registryURL, image, "pull,push", requestURL, nil, nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+
+	return true, nil
+}
+
+func (client *nexusclient) UploadDockerBlob(registryURL string, image string, digest string, localPath string) error {
+	client.logger.LogSimpleMessageAndSay("Uploading blob '%s' of image '%s' to registry '%s'", digest, image, registryURL)
+
+	localFile, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer localFile.Close()
+
+	fileInfo, err := localFile.Stat()
+	if err != nil {
+		return err
+	}
+	size := fileInfo.Size()
+
+	requestURL := strings.TrimSuffix(registryURL, "/") + "/v2/" + image + "/blobs/uploads/"
+	resp, err := client.doRegistryRequest(http.MethodPost, registryURL, image, "pull,push", requestURL, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	location, err := uploadLocation(resp)
+	if err != nil {
+		return err
+	}
+
+	// Small blobs are uploaded with the final PUT, larger ones are first sent
+	// in chunks with PATCH requests
+	chunk := make([]byte, dockerBlobChunkSize)
+	var body []byte
+	if size <= dockerBlobChunkSize {
+		n, err := io.ReadFull(localFile, chunk[:size])
+		if err != nil {
+			return err
+		}
+		body = chunk[:n]
+	} else {
+		for offset := int64(0); offset < size; {
+			n, err := io.ReadFull(localFile, chunk)
+			if err != nil && err != io.ErrUnexpectedEOF {
+				return err
+			}
+
+			header := http.Header{}
+			header.Set("Content-Type", "application/octet-stream")
+			header.Set("Content-Range", strconv.FormatInt(offset, 10)+"-"+strconv.FormatInt(offset+int64(n)-1, 10))
+			resp, err = client.doRegistryRequest(http.MethodPatch, registryURL, image, "pull,push", location, header, bytes.NewReader(chunk[:n]))
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+
+			location, err = uploadLocation(resp)
+			if err != nil {
+				return err
+			}
+			offset += int64(n)
+		}
+	}
+
+	u, err := url.Parse(location)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	q.Set("digest", digest)
+	u.RawQuery = q.Encode()
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/octet-stream")
+	resp, err = client.doRegistryRequest(http.MethodPut, registryURL, image, "pull,push", u.String(), header, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func (client *nexusclient) PutDockerManifest(registryURL string, image string, reference string, mediaType string, content []byte) error {
+	client.logger.LogSimpleMessageAndSay("Pushing manifest of image '%s' with reference '%s' to registry '%s'", image, reference, registryURL)
+
+	header := http.Header{}
+	header.Set("Content-Type", mediaType)
+
+	requestURL := strings.TrimSuffix(registryURL, "/") + "/v2/" + image + "/manifests/" + reference
+	resp, err := client.doRegistryRequest(http.MethodPut, registryURL, image, "pull,push", requestURL, header, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// uploadLocation returns the absolute URL of the Location of a blob upload
+func uploadLocation(resp *http.Response) (string, error) {
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("uploadLocation: no location received for the blob upload")
+	}
+
+	u, err := resp.Request.URL.Parse(location)
+	if err != nil {
+		return "", err
+	}
+
+	return u.String(), nil
+}
+
+// doRegistryRequest executes a request against the registry v2 API, with
+// either basic authentication or a bearer token as challenged by the registry.
+// On a non-successful status code the closed response is returned with the error
+func (client *nexusclient) doRegistryRequest(method string, registryURL string, image string, actions string, requestURL string, header http.Header, body io.Reader) (*http.Response, error) {
+	authorization, err := client.registryAuthorization(registryURL, image, actions)
+	if err != nil {
+		return nil, err
+	}
+
+	client.logger.LogHTTPRequest(method, requestURL)
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !(resp.StatusCode >= 200 && resp.StatusCode <= 299) {
+		resp.Body.Close()
+		return resp, fmt.Errorf("doRegistryRequest: non-successful status code received %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// registryAuthorization returns the Authorization header for the scope,
+// following the challenge returned by the base endpoint of the registry
+func (client *nexusclient) registryAuthorization(registryURL string, image string, actions string) (string, error) {
+	scope := "repository:" + image + ":" + actions
+
+	// Held until the token is stored, so concurrent requests fetch it once
+	client.registryTokensMutex.Lock()
+	defer client.registryTokensMutex.Unlock()
+
+	if authorization, ok := client.registryTokens[registryURL+" "+scope]; ok {
+		return authorization, nil
+	}
+
+	basic := ""
+	if client.username != "" {
+		basic = "Basic " + base64.StdEncoding.EncodeToString([]byte(client.username+":"+client.password))
+	}
+
+	baseURL := strings.TrimSuffix(registryURL, "/") + "/v2/"
+	client.logger.LogHTTPRequest(http.MethodGet, baseURL)
+	resp, err := client.httpClient.Get(baseURL)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	if resp.StatusCode != http.StatusUnauthorized || !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		client.registryTokens[registryURL+" "+scope] = basic
+		return basic, nil
+	}
+
+	parameters := map[string]string{}
+	for _, match := range challengeParameterPattern.FindAllStringSubmatch(challenge, -1) {
+		parameters[match[1]] = match[2]
+	}
+
+	u, err := url.Parse(parameters["realm"])
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	if parameters["service"] != "" {
+		q.Set("service", parameters["service"])
+	}
+	q.Set("scope", scope)
+	u.RawQuery = q.Encode()
+
+	client.logger.LogHTTPRequest(http.MethodGet, u.String())
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if basic != "" {
+		req.Header.Set("Authorization", basic)
+	}
+
+	resp, err = client.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registryAuthorization: received invalid status code %d", resp.StatusCode)
+	}
+
+	var token models.DockerToken
+	err = json.NewDecoder(resp.Body).Decode(&token)
+	if err != nil {
+		return "", err
+	}
+
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+
+	authorization := "Bearer " + token.Token
+	client.registryTokens[registryURL+" "+scope] = authorization
+	return authorization, nil
+}
@@ -0,0 +1,66 @@
+package nexusresource_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+var _ = Describe("Docker registry", func() {
+	var (
+		server        *httptest.Server
+		tokenRequests int32
+	)
+
+	BeforeEach(func() {
+		tokenRequests = 0
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer pull-token" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="nexus"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+			w.Header().Set("Docker-Content-Digest", "sha256:"+r.URL.Path[len("/v2/team/api/manifests/"):])
+			w.Write([]byte("{}"))
+		})
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&tokenRequests, 1)
+			w.Write([]byte(`{"token":"pull-token"}`))
+		})
+
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("shares the token of a scope between concurrent requests", func() {
+		client := nexusresource.NewNexusClient(server.URL, "user", "password", 0, false)
+
+		tags := []string{"v1.0.0", "v1.1.0", "v1.2.0", "v1.3.0", "v1.4.0", "v1.5.0", "v1.6.0", "v1.7.0", "v1.8.0", "v1.9.0"}
+		digests := make([]string, len(tags))
+		err := utils.ForEachConcurrently(len(tags), func(i int) error {
+			descriptor, _, err := client.GetDockerManifest(server.URL, "team/api", tags[i])
+			digests[i] = descriptor.Digest
+			return err
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		for i, tag := range tags {
+			Ω(digests[i]).Should(Equal("sha256:" + tag))
+		}
+		Ω(atomic.LoadInt32(&tokenRequests)).Should(Equal(int32(1)))
+	})
+})
@@ -3,6 +3,8 @@ module github.com/trecnoc/nexus-resource
 go 1.20
 
 require (
+	github.com/Masterminds/semver/v3 v3.2.1
+	github.com/bmatcuk/doublestar/v4 v4.9.1
 	github.com/cppforlife/go-semi-semantic v0.0.0-20160921010311-576b6af77ae4
 	github.com/h2non/filetype v1.1.3
 	github.com/maxbrunsfeld/counterfeiter/v6 v6.6.1
@@ -10,6 +12,8 @@ require (
 	github.com/onsi/ginkgo v1.16.5
 	github.com/onsi/gomega v1.27.1
 	github.com/sirupsen/logrus v1.9.0
+	golang.org/x/mod v0.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -18,11 +22,9 @@ require (
 	github.com/joefitzgerald/rainbow-reporter v0.1.0 // indirect
 	github.com/konsorten/go-windows-terminal-sequences v1.0.1 // indirect
 	github.com/nxadm/tail v1.4.8 // indirect
-	golang.org/x/mod v0.7.0 // indirect
 	golang.org/x/net v0.7.0 // indirect
 	golang.org/x/sys v0.5.0 // indirect
 	golang.org/x/text v0.7.0 // indirect
 	golang.org/x/tools v0.5.0 // indirect
 	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,58 @@
+package in
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// verifyAptPackage checks the downloaded .deb against its SHA256 in the Packages index
+func (command *Command) verifyAptPackage(request Request, remotePath string, localPath string) error {
+	aptPackage, err := findAptPackage(command.nexusclient, request, remotePath)
+	if err != nil {
+		return err
+	}
+
+	if aptPackage.SHA256 == "" {
+		return nil
+	}
+
+	digest, err := fileDigest(localPath, sha256.New())
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(digest) != aptPackage.SHA256 {
+		return fmt.Errorf("sha256 mismatch for %s: expected %s", localPath, aptPackage.SHA256)
+	}
+
+	return nil
+}
+
+func (provider *MetadataProvider) aptSHA(request Request, remotePath string) string {
+	aptPackage, err := findAptPackage(provider.nexusClient, request, remotePath)
+	if err != nil {
+		return ""
+	}
+
+	return aptPackage.SHA256
+}
+
+func findAptPackage(client nexusresource.NexusClient, request Request, remotePath string) (models.AptPackage, error) {
+	packages, err := versions.GetAptPackages(client, request.Source.Repository, request.Source.Apt)
+	if err != nil {
+		return models.AptPackage{}, err
+	}
+
+	for _, aptPackage := range packages {
+		if aptPackage.Filename == remotePath {
+			return aptPackage, nil
+		}
+	}
+
+	return models.AptPackage{}, fmt.Errorf("%s not found in %s", remotePath, request.Source.Apt.PackagesPath())
+}
@@ -0,0 +1,51 @@
+package in
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/trecnoc/nexus-resource/models"
+)
+
+// writeCaptureFiles writes the value of each named group of the regexp to a
+// file named after the group, the version group is already in the version file,
+// and all of them to captures.json
+func (command *Command) writeCaptureFiles(destDir string, captures map[string]string) error {
+	for name, value := range captures {
+		if name == "version" {
+			continue
+		}
+
+		err := ioutil.WriteFile(filepath.Join(destDir, name), []byte(value), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	content, err := json.Marshal(captures)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(destDir, "captures.json"), content, 0644)
+}
+
+func (command *Command) captureMetadata(captures map[string]string) []models.MetadataPair {
+	names := make([]string, 0, len(captures))
+	for name := range captures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	metadata := []models.MetadataPair{}
+	for _, name := range names {
+		metadata = append(metadata, models.MetadataPair{
+			Name:  name,
+			Value: captures[name],
+		})
+	}
+
+	return metadata
+}
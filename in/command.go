@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/trecnoc/nexus-resource"
 	"github.com/trecnoc/nexus-resource/models"
@@ -36,9 +37,37 @@ func (provider *MetadataProvider) GetSHA(request Request, remotePath string) str
 }
 
 func (provider *MetadataProvider) nexusSHA(request Request, remotePath string) string {
+	switch request.Source.Format {
+	case models.FormatMaven2:
+		return provider.mavenSHA(request, remotePath)
+	case models.FormatNpm:
+		return provider.npmSHA(request, remotePath)
+	case models.FormatPypi:
+		return provider.pypiSHA(request, remotePath)
+	case models.FormatHelm:
+		return provider.helmSHA(request, remotePath)
+	case models.FormatNuGet:
+		return provider.nugetSHA(request, remotePath)
+	case models.FormatApt:
+		return provider.aptSHA(request, remotePath)
+	case models.FormatYum:
+		return provider.yumSHA(request, remotePath)
+	}
+
 	return provider.nexusClient.SHA(request.Source.Repository, remotePath)
 }
 
+// Maven artifacts are searched by coordinates, so the SHA is read from the
+// checksum file deployed next to the artifact instead
+func (provider *MetadataProvider) mavenSHA(request Request, remotePath string) string {
+	content, err := provider.nexusClient.GetFile(request.Source.Repository, remotePath+".sha1")
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(content))
+}
+
 // Command struct for In
 type Command struct {
 	nexusclient      nexusresource.NexusClient
@@ -61,7 +90,13 @@ func (command *Command) Run(destinationDir string, request Request) (Response, e
 		return Response{}, errors.New(message)
 	}
 
-	err := os.MkdirAll(destinationDir, 0755)
+	source, err := versions.ResolveFormat(command.nexusclient, request.Source)
+	if err != nil {
+		return Response{}, err
+	}
+	request.Source = source
+
+	err = os.MkdirAll(destinationDir, 0755)
 	if err != nil {
 		return Response{}, err
 	}
@@ -71,18 +106,36 @@ func (command *Command) Run(destinationDir string, request Request) (Response, e
 	var url string
 	var sha string
 
-	if request.Version.Path == "" {
+	remotePath = request.Version.Path
+	if request.Source.VersionIdentity == models.VersionIdentityVersion && request.Version.VersionNumber != "" {
+		remotePath, err = versions.ResolveVersionPath(command.nexusclient, request.Source, request.Version.VersionNumber)
+		if err != nil {
+			return Response{}, err
+		}
+	}
+
+	if remotePath == "" {
 		return Response{}, ErrMissingPath
 	}
 
-	remotePath = request.Version.Path
-	extraction, ok := versions.Extract(remotePath, request.Source.Regexp)
+	extraction, ok := versions.ExtractVersion(remotePath, request.Source)
 	if !ok {
 		return Response{}, fmt.Errorf("regex does not match provided version: %#v", request.Version)
 	}
 
+	if request.Source.Format == models.FormatDocker {
+		return command.runDocker(destinationDir, request, extraction)
+	}
+
 	versionNumber = extraction.VersionNumber
 
+	if request.Source.Format == models.FormatRaw {
+		err = command.verifyRawAsset(request, remotePath)
+		if err != nil {
+			return Response{}, err
+		}
+	}
+
 	if !request.Params.SkipDownload {
 		err = command.downloadFile(
 			request.Source.Repository,
@@ -94,6 +147,27 @@ func (command *Command) Run(destinationDir string, request Request) (Response, e
 			return Response{}, err
 		}
 
+		localPath := filepath.Join(destinationDir, path.Base(remotePath))
+		switch request.Source.Format {
+		case models.FormatRaw:
+			err = command.verifyRawFile(request, localPath)
+		case models.FormatNpm:
+			err = command.verifyNpmTarball(request, versionNumber, localPath)
+		case models.FormatPypi:
+			err = command.verifyPypiFile(request, remotePath, localPath)
+		case models.FormatHelm:
+			err = command.verifyHelmChart(request, versionNumber, localPath)
+		case models.FormatGo:
+			err = command.verifyGoModule(request, versionNumber, localPath)
+		case models.FormatApt:
+			err = command.verifyAptPackage(request, remotePath, localPath)
+		case models.FormatYum:
+			err = command.verifyYumPackage(request, remotePath, localPath)
+		}
+		if err != nil {
+			return Response{}, err
+		}
+
 		if request.Params.Unpack {
 			destinationPath := filepath.Join(destinationDir, path.Base(remotePath))
 			mime := archiveMimetype(destinationPath)
@@ -125,12 +199,28 @@ func (command *Command) Run(destinationDir string, request Request) (Response, e
 		return Response{}, err
 	}
 
+	if len(extraction.Captures) > 0 {
+		err = command.writeCaptureFiles(destinationDir, extraction.Captures)
+		if err != nil {
+			return Response{}, err
+		}
+	}
+
 	metadata := command.metadata(remotePath, url, sha)
+	metadata = append(metadata, command.captureMetadata(extraction.Captures)...)
+	if request.Source.VersionIdentity == models.VersionIdentityVersion {
+		metadata = append(metadata, models.MetadataPair{Name: "path", Value: remotePath})
+	}
+	switch request.Source.Format {
+	case models.FormatHelm:
+		metadata = append(metadata, command.helmMetadata(request, versionNumber)...)
+	case models.FormatGo:
+		localPath := filepath.Join(destinationDir, path.Base(remotePath))
+		metadata = append(metadata, command.goMetadata(request, versionNumber, localPath)...)
+	}
 
 	return Response{
-		Version: models.Version{
-			Path: remotePath,
-		},
+		Version:  request.Version,
 		Metadata: metadata,
 	}, nil
 }
@@ -4,6 +4,11 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"io"
 	"io/ioutil"
 	"log"
@@ -11,6 +16,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -51,6 +57,7 @@ var _ = Describe("In Command", func() {
 			}
 
 			nexusclient = &fakes.FakeNexusClient{}
+			nexusclient.GetRepositoryReturns(models.Repository{Name: "repository-name", Format: "raw", Type: "hosted"}, nil)
 			command = NewCommand(nexusclient)
 
 			nexusclient.URLReturns("http://nexus-url.com/files/a-file-1.3")
@@ -82,6 +89,53 @@ var _ = Describe("In Command", func() {
 			})
 		})
 
+		Context("when the version number is the identity of the versions", func() {
+			BeforeEach(func() {
+				request.Source.VersionIdentity = models.VersionIdentityVersion
+				request.Source.Group = "/release"
+				request.Source.Regexp = "(?:staging|release)/app-(.*).tgz"
+				request.Version = models.Version{VersionNumber: "1.3.0"}
+
+				nexusclient.ListFilesReturns([]string{"release/app-1.2.0.tgz", "release/app-1.3.0.tgz"}, nil)
+			})
+
+			It("downloads the current path of the version", func() {
+				response, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, remotePath, _ := nexusclient.DownloadFileArgsForCall(0)
+				Ω(remotePath).Should(Equal("release/app-1.3.0.tgz"))
+
+				Ω(response.Version).Should(Equal(models.Version{VersionNumber: "1.3.0"}))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "path", Value: "release/app-1.3.0.tgz"}))
+			})
+
+			It("errors when the version isn't found", func() {
+				request.Version = models.Version{VersionNumber: "1.4.0"}
+
+				_, err := command.Run(destDir, request)
+				Ω(err).Should(MatchError("version 1.4.0 was not found in the repository"))
+			})
+
+			It("resolves versions the filters of check exclude by now", func() {
+				request.Source.VersionConstraint = "<1.3.0"
+				request.Source.IgnoreGlobs = []string{"release/*-1.3.0.tgz"}
+
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, remotePath, _ := nexusclient.DownloadFileArgsForCall(0)
+				Ω(remotePath).Should(Equal("release/app-1.3.0.tgz"))
+			})
+
+			It("returns the error of listing the versions", func() {
+				nexusclient.ListFilesReturns(nil, errors.New("doGetResquest: non-successful status code received 500"))
+
+				_, err := command.Run(destDir, request)
+				Ω(err).Should(MatchError("listing files: doGetResquest: non-successful status code received 500"))
+			})
+		})
+
 		Context("when configured to skip download", func() {
 			BeforeEach(func() {
 				request.Params.SkipDownload = true
@@ -182,9 +236,540 @@ var _ = Describe("In Command", func() {
 			})
 		})
 
+		Context("when the regexp has named groups", func() {
+			BeforeEach(func() {
+				request.Source.Regexp = `files/app-(?P<version>[^-]*)-(?P<build>\d+)-(?P<commit>[0-9a-f]+).zip`
+				request.Version.Path = "files/app-1.2.0-42-a1b2c3d.zip"
+			})
+
+			It("writes each group to a file", func() {
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				contents, err := ioutil.ReadFile(filepath.Join(destDir, "build"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("42"))
+
+				contents, err = ioutil.ReadFile(filepath.Join(destDir, "commit"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("a1b2c3d"))
+
+				contents, err = ioutil.ReadFile(filepath.Join(destDir, "version"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("1.2.0"))
+			})
+
+			It("writes all the groups to captures.json", func() {
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				contents, err := ioutil.ReadFile(filepath.Join(destDir, "captures.json"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(contents).Should(MatchJSON(`{"version": "1.2.0", "build": "42", "commit": "a1b2c3d"}`))
+			})
+
+			It("has metadata about the groups", func() {
+				response, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "build", Value: "42"}))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "commit", Value: "a1b2c3d"}))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "version", Value: "1.2.0"}))
+			})
+		})
+
+		Context("when the version includes the content of the asset", func() {
+			var digest [32]byte
+
+			BeforeEach(func() {
+				digest = sha256.Sum256([]byte("some-contents"))
+				request.Source.TrackRedeploys = models.TrackRedeploysSha256
+				request.Version.Sha256 = hex.EncodeToString(digest[:])
+
+				nexusclient.SearchComponentsReturns([]models.RepositoryItem{{
+					Name: "files/a-file-1.3",
+					Assets: []models.RepositoryItemAsset{{
+						Path:         "files/a-file-1.3",
+						Checksum:     models.RepositoryItemAssetsChecksum{Sha256: hex.EncodeToString(digest[:])},
+						LastModified: time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC),
+					}},
+				}}, nil)
+				nexusclient.DownloadFileStub = func(repositoryName string, remotePath string, localPath string) error {
+					return ioutil.WriteFile(localPath, []byte("some-contents"), 0644)
+				}
+			})
+
+			It("fetches the asset with that sha256", func() {
+				response, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				repositoryName, parameters := nexusclient.SearchComponentsArgsForCall(0)
+				Ω(repositoryName).Should(Equal("repository-name"))
+				Ω(parameters).Should(Equal(map[string]string{"name": "files/a-file-1.3"}))
+				Ω(response.Version).Should(Equal(request.Version))
+			})
+
+			It("errors when the asset was redeployed since check", func() {
+				request.Version.Sha256 = "0123"
+
+				_, err := command.Run(destDir, request)
+				Ω(err).Should(MatchError(ContainSubstring("files/a-file-1.3 was redeployed since the version was checked")))
+				Ω(nexusclient.DownloadFileCallCount()).Should(Equal(0))
+			})
+
+			It("errors when the downloaded file has another sha256", func() {
+				nexusclient.DownloadFileStub = func(repositoryName string, remotePath string, localPath string) error {
+					return ioutil.WriteFile(localPath, []byte("redeployed-contents"), 0644)
+				}
+
+				_, err := command.Run(destDir, request)
+				Ω(err).Should(MatchError(ContainSubstring("sha256 mismatch")))
+			})
+
+			It("checks the last modification of the asset", func() {
+				request.Source.TrackRedeploys = models.TrackRedeploysLastModified
+				request.Version.Sha256 = ""
+				request.Version.LastModified = "2026-10-17T03:00:00Z"
+
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				request.Version.LastModified = "2026-10-16T03:00:00Z"
+
+				_, err = command.Run(destDir, request)
+				Ω(err).Should(MatchError(ContainSubstring("last modified 2026-10-17T03:00:00Z, expected 2026-10-16T03:00:00Z")))
+			})
+		})
+
+		Context("when the format is maven2", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatMaven2
+				request.Source.Maven = models.MavenSource{
+					GroupID:    "com.example",
+					ArtifactID: "app",
+					Version:    "1.2.0-SNAPSHOT",
+				}
+				request.Version.Path = "com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261001.123456-7.jar"
+
+				nexusclient.GetFileReturns([]byte("5b1f8c6e0a2c7d9e3f4a1b2c3d4e5f6a7b8c9d0e\n"), nil)
+			})
+
+			It("downloads the timestamped build", func() {
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, remotePath, localPath := nexusclient.DownloadFileArgsForCall(0)
+				Ω(remotePath).Should(Equal("com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261001.123456-7.jar"))
+				Ω(localPath).Should(Equal(filepath.Join(destDir, "app-1.2.0-20261001.123456-7.jar")))
+			})
+
+			It("creates a 'version' file that contains the timestamped version", func() {
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				contents, err := ioutil.ReadFile(filepath.Join(destDir, "version"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("1.2.0-20261001.123456-7"))
+			})
+
+			It("reads the SHA from the deployed checksum file", func() {
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, name := nexusclient.GetFileArgsForCall(0)
+				Ω(name).Should(Equal("com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261001.123456-7.jar.sha1"))
+
+				contents, err := ioutil.ReadFile(filepath.Join(destDir, "sha"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("5b1f8c6e0a2c7d9e3f4a1b2c3d4e5f6a7b8c9d0e"))
+				Ω(nexusclient.SHACallCount()).Should(Equal(0))
+			})
+		})
+
+		Context("when the format is npm", func() {
+			var integrity string
+
+			BeforeEach(func() {
+				request.Source.Format = models.FormatNpm
+				request.Source.Npm = models.NpmSource{Package: "@frontend/widgets"}
+				request.Version.Path = "@frontend/widgets/-/widgets-2.1.0.tgz"
+
+				digest := sha512.Sum512([]byte("some-contents"))
+				integrity = "sha512-" + base64.StdEncoding.EncodeToString(digest[:])
+
+				nexusclient.DownloadFileStub = func(repositoryName string, remotePath string, localPath string) error {
+					return ioutil.WriteFile(localPath, []byte("some-contents"), 0644)
+				}
+				nexusclient.GetFileStub = func(repositoryName string, name string) ([]byte, error) {
+					return []byte(`{
+  "name": "@frontend/widgets",
+  "versions": {
+    "2.1.0": {"dist": {"shasum": "2bd3d5a9f1d2ff6c3d4fe1e8a3e9a3d2b2b7e6a1", "integrity": "` + integrity + `"}}
+  }
+}`), nil
+				}
+			})
+
+			It("downloads the tarball and creates a 'version' file", func() {
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, remotePath, localPath := nexusclient.DownloadFileArgsForCall(0)
+				Ω(remotePath).Should(Equal("@frontend/widgets/-/widgets-2.1.0.tgz"))
+				Ω(localPath).Should(Equal(filepath.Join(destDir, "widgets-2.1.0.tgz")))
+
+				contents, err := ioutil.ReadFile(filepath.Join(destDir, "version"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("2.1.0"))
+			})
+
+			It("writes the shasum of the package to the 'sha' file", func() {
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				contents, err := ioutil.ReadFile(filepath.Join(destDir, "sha"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("2bd3d5a9f1d2ff6c3d4fe1e8a3e9a3d2b2b7e6a1"))
+			})
+
+			It("errors when the tarball doesn't match the integrity", func() {
+				nexusclient.DownloadFileStub = func(repositoryName string, remotePath string, localPath string) error {
+					return ioutil.WriteFile(localPath, []byte("tampered-contents"), 0644)
+				}
+
+				_, err := command.Run(destDir, request)
+				Ω(err).Should(HaveOccurred())
+				Ω(err.Error()).Should(ContainSubstring("integrity mismatch"))
+			})
+		})
+
+		Context("when the format is pypi", func() {
+			var digest [sha256.Size]byte
+
+			BeforeEach(func() {
+				request.Source.Format = models.FormatPypi
+				request.Source.Pypi = models.PypiSource{Package: "friendly-bard"}
+				request.Version.Path = "packages/friendly-bard/1.1.0/friendly-bard-1.1.0.tar.gz"
+
+				digest = sha256.Sum256([]byte("some-contents"))
+				nexusclient.DownloadFileStub = func(repositoryName string, remotePath string, localPath string) error {
+					return ioutil.WriteFile(localPath, []byte("some-contents"), 0644)
+				}
+				nexusclient.GetFileReturns([]byte(`<a href="../../packages/friendly-bard/1.1.0/friendly-bard-1.1.0.tar.gz#sha256=`+hex.EncodeToString(digest[:])+`">friendly-bard-1.1.0.tar.gz</a>`), nil)
+			})
+
+			It("downloads the distribution and writes its sha256", func() {
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				contents, err := ioutil.ReadFile(filepath.Join(destDir, "friendly-bard-1.1.0.tar.gz"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("some-contents"))
+
+				contents, err = ioutil.ReadFile(filepath.Join(destDir, "sha"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal(hex.EncodeToString(digest[:])))
+
+				contents, err = ioutil.ReadFile(filepath.Join(destDir, "version"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("1.1.0"))
+			})
+
+			It("errors when the distribution doesn't match the index sha256", func() {
+				nexusclient.DownloadFileStub = func(repositoryName string, remotePath string, localPath string) error {
+					return ioutil.WriteFile(localPath, []byte("tampered-contents"), 0644)
+				}
+
+				_, err := command.Run(destDir, request)
+				Ω(err).Should(HaveOccurred())
+				Ω(err.Error()).Should(ContainSubstring("sha256 mismatch"))
+			})
+		})
+
+		Context("when the format is go", func() {
+			var hash string
+
+			BeforeEach(func() {
+				request.Source.Format = models.FormatGo
+				request.Source.Repository = "go-proxy"
+				request.Source.Go = models.GoSource{Module: "github.com/contoso/billing"}
+				request.Version.Path = "github.com/contoso/billing/@v/v1.2.0.zip"
+
+				nexusclient.DownloadFileStub = func(repositoryName string, remotePath string, localPath string) error {
+					file, err := os.Create(localPath)
+					if err != nil {
+						return err
+					}
+					defer file.Close()
+
+					zipWriter := zip.NewWriter(file)
+					writer, err := zipWriter.Create("github.com/contoso/billing@v1.2.0/go.mod")
+					if err != nil {
+						return err
+					}
+					_, err = writer.Write([]byte("module github.com/contoso/billing\n"))
+					if err != nil {
+						return err
+					}
+					return zipWriter.Close()
+				}
+				nexusclient.GetFileReturns([]byte(`{"Version":"v1.2.0","Time":"2026-10-01T12:34:56Z"}`), nil)
+
+				// h1 hash of the zip as computed by the go command
+				digest := sha256.Sum256([]byte("module github.com/contoso/billing\n"))
+				summary := sha256.Sum256([]byte(hex.EncodeToString(digest[:]) + "  github.com/contoso/billing@v1.2.0/go.mod\n"))
+				hash = "h1:" + base64.StdEncoding.EncodeToString(summary[:])
+			})
+
+			It("verifies the module zip against the go.sum line", func() {
+				request.Params.GoSum = "github.com/contoso/billing v1.2.0 " + hash
+
+				response, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "h1", Value: hash}))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "time", Value: "2026-10-01T12:34:56Z"}))
+
+				_, name := nexusclient.GetFileArgsForCall(0)
+				Ω(name).Should(Equal("github.com/contoso/billing/@v/v1.2.0.info"))
+			})
+
+			It("errors when the go.sum hash doesn't match", func() {
+				request.Params.GoSum = "github.com/contoso/billing v1.2.0 h1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+
+				_, err := command.Run(destDir, request)
+				Ω(err).Should(HaveOccurred())
+				Ω(err.Error()).Should(HavePrefix("go.sum hash mismatch"))
+			})
+
+			It("errors when the go.sum line is for another version", func() {
+				request.Params.GoSum = "github.com/contoso/billing v1.1.0 " + hash
+
+				_, err := command.Run(destDir, request)
+				Ω(err).Should(MatchError("go_sum is the line of github.com/contoso/billing v1.1.0, expected github.com/contoso/billing v1.2.0"))
+			})
+		})
+
+		Context("when the format is apt", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatApt
+				request.Source.Apt = models.AptSource{Package: "billing-agent", Distribution: "jammy"}
+				request.Version.Path = "pool/b/billing-agent/billing-agent_1.2.0-1_amd64.deb"
+
+				digest := sha256.Sum256([]byte("some-contents"))
+				nexusclient.DownloadFileStub = func(repositoryName string, remotePath string, localPath string) error {
+					return ioutil.WriteFile(localPath, []byte("some-contents"), 0644)
+				}
+				nexusclient.GetFileReturns([]byte(`Package: billing-agent
+Version: 1.2.0-1
+Filename: pool/b/billing-agent/billing-agent_1.2.0-1_amd64.deb
+SHA256: `+hex.EncodeToString(digest[:])+`
+`), nil)
+			})
+
+			It("downloads the package and writes its SHA256", func() {
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				digest := sha256.Sum256([]byte("some-contents"))
+				contents, err := ioutil.ReadFile(filepath.Join(destDir, "sha"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal(hex.EncodeToString(digest[:])))
+
+				contents, err = ioutil.ReadFile(filepath.Join(destDir, "version"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("1.2.0-1"))
+			})
+
+			It("errors when the package doesn't match its SHA256", func() {
+				nexusclient.DownloadFileStub = func(repositoryName string, remotePath string, localPath string) error {
+					return ioutil.WriteFile(localPath, []byte("other-contents"), 0644)
+				}
+
+				_, err := command.Run(destDir, request)
+				Ω(err).Should(HaveOccurred())
+				Ω(err.Error()).Should(HavePrefix("sha256 mismatch"))
+			})
+		})
+
+		Context("when the format is nuget", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatNuGet
+				request.Source.Repository = "nuget-hosted"
+				request.Source.NuGet = models.NuGetSource{Package: "Contoso.Billing"}
+				request.Version.Path = "v3-flatcontainer/contoso.billing/2.1.0/contoso.billing.2.1.0.nupkg"
+
+				nexusclient.SearchComponentsReturns([]models.RepositoryItem{
+					{
+						Name:    "Contoso.Billing",
+						Version: "2.1.0",
+						Assets: []models.RepositoryItemAsset{
+							{Checksum: models.RepositoryItemAssetsChecksum{Sha1: "0a4d55a8d778e5022fab701977c5d840bbc486d0"}},
+						},
+					},
+				}, nil)
+			})
+
+			It("downloads the package and writes the SHA of its component", func() {
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, remotePath, localPath := nexusclient.DownloadFileArgsForCall(0)
+				Ω(remotePath).Should(Equal("v3-flatcontainer/contoso.billing/2.1.0/contoso.billing.2.1.0.nupkg"))
+				Ω(localPath).Should(Equal(filepath.Join(destDir, "contoso.billing.2.1.0.nupkg")))
+
+				_, params := nexusclient.SearchComponentsArgsForCall(0)
+				Ω(params).Should(Equal(map[string]string{"format": "nuget", "name": "Contoso.Billing", "version": "2.1.0"}))
+
+				contents, err := ioutil.ReadFile(filepath.Join(destDir, "sha"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("0a4d55a8d778e5022fab701977c5d840bbc486d0"))
+
+				contents, err = ioutil.ReadFile(filepath.Join(destDir, "version"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("2.1.0"))
+			})
+		})
+
+		Context("when the format is docker", func() {
+			var manifest []byte
+
+			BeforeEach(func() {
+				request.Source.Format = models.FormatDocker
+				request.Source.Repository = "docker-hosted"
+				request.Source.Regexp = `v(\d+\.\d+\.\d+)`
+				request.Source.Docker = models.DockerSource{Image: "team/api"}
+				request.Version = models.Version{
+					Path:   "v2/team/api/manifests/v1.4.2",
+					Digest: models.DockerDigest([]byte("manifest")),
+				}
+
+				manifest = []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"digest":"sha256:c0","size":2},"layers":[{"digest":"sha256:l1","size":5}]}`)
+				nexusclient.GetDockerManifestReturns(models.DockerDescriptor{
+					MediaType: models.OCIImageManifestMediaType,
+					Digest:    models.DockerDigest([]byte("manifest")),
+				}, manifest, nil)
+				nexusclient.DownloadDockerBlobStub = func(registryURL string, image string, digest string, localPath string) error {
+					return ioutil.WriteFile(localPath, []byte(digest), 0644)
+				}
+			})
+
+			It("writes the reference files without downloading the blobs", func() {
+				response, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, image, reference := nexusclient.GetDockerManifestArgsForCall(0)
+				Ω(image).Should(Equal("team/api"))
+				Ω(reference).Should(Equal(request.Version.Digest))
+				Ω(nexusclient.DownloadDockerBlobCallCount()).Should(Equal(0))
+
+				for name, value := range map[string]string{
+					"tag":        "v1.4.2",
+					"digest":     request.Version.Digest,
+					"repository": "nexus-url.com/repository/docker-hosted/team/api",
+					"version":    "1.4.2",
+				} {
+					contents, err := ioutil.ReadFile(filepath.Join(destDir, name))
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(string(contents)).Should(Equal(value))
+				}
+
+				Ω(response.Version).Should(Equal(request.Version))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "tag", Value: "v1.4.2"}))
+			})
+
+			It("saves an OCI image tarball when asked", func() {
+				request.Params.SaveOCITarball = true
+
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.DownloadDockerBlobCallCount()).Should(Equal(2))
+
+				tarball, err := os.Open(filepath.Join(destDir, "image.tar"))
+				Ω(err).ShouldNot(HaveOccurred())
+				defer tarball.Close()
+
+				var names []string
+				tr := tar.NewReader(tarball)
+				for {
+					header, err := tr.Next()
+					if err == io.EOF {
+						break
+					}
+					Ω(err).ShouldNot(HaveOccurred())
+					names = append(names, header.Name)
+				}
+
+				Ω(names).Should(ConsistOf(
+					"oci-layout",
+					"index.json",
+					"blobs/sha256/c0",
+					"blobs/sha256/l1",
+					"blobs/sha256/"+strings.TrimPrefix(request.Version.Digest, "sha256:"),
+				))
+			})
+		})
+
+		Context("when the format is helm", func() {
+			var digest [sha256.Size]byte
+
+			BeforeEach(func() {
+				request.Source.Format = models.FormatHelm
+				request.Source.Helm = models.HelmSource{Chart: "ingress-gateway"}
+				request.Version.Path = "ingress-gateway-0.4.1.tgz"
+
+				digest = sha256.Sum256([]byte("some-contents"))
+				nexusclient.DownloadFileStub = func(repositoryName string, remotePath string, localPath string) error {
+					return ioutil.WriteFile(localPath, []byte("some-contents"), 0644)
+				}
+				nexusclient.GetFileReturns([]byte(`entries:
+  ingress-gateway:
+  - name: ingress-gateway
+    version: 0.4.1
+    appVersion: "2.8.1"
+    description: Ingress gateway for the platform
+    digest: `+hex.EncodeToString(digest[:])+`
+    urls:
+    - ingress-gateway-0.4.1.tgz
+`), nil)
+			})
+
+			It("downloads the chart and writes its digest", func() {
+				_, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				contents, err := ioutil.ReadFile(filepath.Join(destDir, "sha"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal(hex.EncodeToString(digest[:])))
+
+				contents, err = ioutil.ReadFile(filepath.Join(destDir, "version"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(contents)).Should(Equal("0.4.1"))
+			})
+
+			It("has the appVersion and description in the metadata", func() {
+				response, err := command.Run(destDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "app_version", Value: "2.8.1"}))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "description", Value: "Ingress gateway for the platform"}))
+			})
+
+			It("errors when the chart doesn't match the digest", func() {
+				nexusclient.DownloadFileStub = func(repositoryName string, remotePath string, localPath string) error {
+					return ioutil.WriteFile(localPath, []byte("tampered-contents"), 0644)
+				}
+
+				_, err := command.Run(destDir, request)
+				Ω(err).Should(HaveOccurred())
+				Ω(err.Error()).Should(ContainSubstring("digest mismatch"))
+			})
+		})
+
 		Context("when the Regexp does not match the provided version", func() {
 			BeforeEach(func() {
-				request.Source.Regexp = "not-matching-anything"
+				request.Source.Regexp = "files/not-matching-(.*)"
 			})
 
 			It("returns an error", func() {
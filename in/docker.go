@@ -0,0 +1,232 @@
+package in
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// default platform of the manifest saved from an index
+const defaultDockerPlatform = "linux/amd64"
+
+// runDocker writes the reference files of an image tag, the image itself is
+// only fetched when saving an OCI image tarball
+func (command *Command) runDocker(destinationDir string, request Request, extraction versions.Extraction) (Response, error) {
+	source := request.Source
+	tag, _ := versions.DockerTag(request.Version.Path, source.Docker.Image)
+
+	reference := request.Version.Digest
+	if reference == "" {
+		reference = tag
+	}
+
+	descriptor, content, err := command.nexusclient.GetDockerManifest(source.DockerRegistryURL(), source.Docker.Image, reference)
+	if err != nil {
+		return Response{}, err
+	}
+
+	if request.Version.Digest != "" && descriptor.Digest != request.Version.Digest {
+		return Response{}, fmt.Errorf("digest mismatch for %s: expected %s", tag, request.Version.Digest)
+	}
+
+	repository := dockerRepository(source)
+
+	files := map[string]string{
+		"tag":        tag,
+		"digest":     descriptor.Digest,
+		"repository": repository,
+		"version":    extraction.VersionNumber,
+	}
+	for name, value := range files {
+		err = ioutil.WriteFile(filepath.Join(destinationDir, name), []byte(value), 0644)
+		if err != nil {
+			return Response{}, err
+		}
+	}
+
+	if request.Params.SaveOCITarball && !request.Params.SkipDownload {
+		err = command.saveOCITarball(destinationDir, request, tag, descriptor, content)
+		if err != nil {
+			return Response{}, err
+		}
+	}
+
+	return Response{
+		Version: request.Version,
+		Metadata: []models.MetadataPair{
+			{Name: "tag", Value: tag},
+			{Name: "digest", Value: descriptor.Digest},
+			{Name: "repository", Value: repository},
+		},
+	}, nil
+}
+
+// dockerRepository returns the image reference without tag, as used by docker pull
+func dockerRepository(source models.Source) string {
+	u, err := url.Parse(source.DockerRegistryURL())
+	if err != nil {
+		return source.Docker.Image
+	}
+
+	return strings.TrimSuffix(u.Host+u.Path, "/") + "/" + source.Docker.Image
+}
+
+// saveOCITarball writes the image as an OCI image layout in image.tar, for an
+// index only the manifest of the requested platform is saved
+func (command *Command) saveOCITarball(destinationDir string, request Request, tag string, descriptor models.DockerDescriptor, content []byte) error {
+	source := request.Source
+	registryURL := source.DockerRegistryURL()
+
+	var manifest models.DockerManifest
+	err := json.Unmarshal(content, &manifest)
+	if err != nil {
+		return fmt.Errorf("reading manifest of %s: %s", tag, err)
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = descriptor.MediaType
+	}
+
+	if manifest.IsIndex() {
+		platform := request.Params.Platform
+		if platform == "" {
+			platform = defaultDockerPlatform
+		}
+
+		platformDescriptor, ok := findPlatformManifest(manifest, platform)
+		if !ok {
+			return fmt.Errorf("no manifest for platform %s in %s", platform, tag)
+		}
+
+		descriptor, content, err = command.nexusclient.GetDockerManifest(registryURL, source.Docker.Image, platformDescriptor.Digest)
+		if err != nil {
+			return err
+		}
+
+		manifest = models.DockerManifest{}
+		err = json.Unmarshal(content, &manifest)
+		if err != nil {
+			return fmt.Errorf("reading manifest of %s: %s", tag, err)
+		}
+	}
+
+	blobsDir, err := ioutil.TempDir(destinationDir, "blobs")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(blobsDir)
+
+	blobs := []models.DockerDescriptor{}
+	if manifest.Config != nil {
+		blobs = append(blobs, *manifest.Config)
+	}
+	blobs = append(blobs, manifest.Layers...)
+
+	for _, blob := range blobs {
+		err = command.nexusclient.DownloadDockerBlob(registryURL, source.Docker.Image, blob.Digest, filepath.Join(blobsDir, digestHex(blob.Digest)))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ioutil.WriteFile(filepath.Join(blobsDir, digestHex(descriptor.Digest)), content, 0644)
+	if err != nil {
+		return err
+	}
+
+	descriptor.Annotations = map[string]string{"org.opencontainers.image.ref.name": tag}
+	index, err := json.Marshal(models.DockerManifest{
+		SchemaVersion: 2,
+		MediaType:     models.OCIImageIndexMediaType,
+		Manifests:     []models.DockerDescriptor{descriptor},
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeOCILayout(filepath.Join(destinationDir, "image.tar"), index, blobsDir)
+}
+
+func findPlatformManifest(index models.DockerManifest, platform string) (models.DockerDescriptor, bool) {
+	for _, manifest := range index.Manifests {
+		if manifest.Platform == nil {
+			continue
+		}
+
+		name := manifest.Platform.OS + "/" + manifest.Platform.Architecture
+		if platform == name || platform == name+"/"+manifest.Platform.Variant {
+			return manifest, true
+		}
+	}
+
+	return models.DockerDescriptor{}, false
+}
+
+func digestHex(digest string) string {
+	return strings.TrimPrefix(digest, "sha256:")
+}
+
+// writeOCILayout writes the tarball of an OCI image layout holding the blobs
+// of the directory
+func writeOCILayout(tarballPath string, index []byte, blobsDir string) error {
+	tarball, err := os.Create(tarballPath)
+	if err != nil {
+		return err
+	}
+	defer tarball.Close()
+
+	tw := tar.NewWriter(tarball)
+
+	writeEntry := func(name string, size int64, r io.Reader) error {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0644,
+			Size: size,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(tw, r)
+		return err
+	}
+
+	layout := `{"imageLayoutVersion":"1.0.0"}`
+	err = writeEntry("oci-layout", int64(len(layout)), strings.NewReader(layout))
+	if err != nil {
+		return err
+	}
+
+	err = writeEntry("index.json", int64(len(index)), strings.NewReader(string(index)))
+	if err != nil {
+		return err
+	}
+
+	fileInfos, err := ioutil.ReadDir(blobsDir)
+	if err != nil {
+		return err
+	}
+
+	for _, fileInfo := range fileInfos {
+		blob, err := os.Open(filepath.Join(blobsDir, fileInfo.Name()))
+		if err != nil {
+			return err
+		}
+
+		err = writeEntry("blobs/sha256/"+fileInfo.Name(), fileInfo.Size(), blob)
+		blob.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
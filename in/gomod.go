@@ -0,0 +1,63 @@
+package in
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// verifyGoModule checks the downloaded module zip against the supplied go.sum line
+func (command *Command) verifyGoModule(request Request, versionNumber string, localPath string) error {
+	if request.Params.GoSum == "" {
+		return nil
+	}
+
+	fields := strings.Fields(request.Params.GoSum)
+	if len(fields) != 3 {
+		return fmt.Errorf("go_sum is not a go.sum line: %s", request.Params.GoSum)
+	}
+
+	if fields[0] != request.Source.Go.Module || fields[1] != versionNumber {
+		return fmt.Errorf("go_sum is the line of %s %s, expected %s %s", fields[0], fields[1], request.Source.Go.Module, versionNumber)
+	}
+
+	hash, err := dirhash.HashZip(localPath, dirhash.Hash1)
+	if err != nil {
+		return err
+	}
+
+	if hash != fields[2] {
+		return fmt.Errorf("go.sum hash mismatch for %s: expected %s, got %s", localPath, fields[2], hash)
+	}
+
+	return nil
+}
+
+// goMetadata returns the commit time of the module version and the go.sum
+// hash of the downloaded zip
+func (command *Command) goMetadata(request Request, versionNumber string, localPath string) []models.MetadataPair {
+	metadata := []models.MetadataPair{}
+
+	info, err := versions.GetGoModuleInfo(command.nexusclient, request.Source.Repository, request.Source.Go, versionNumber)
+	if err == nil && !info.Time.IsZero() {
+		metadata = append(metadata, models.MetadataPair{
+			Name:  "time",
+			Value: info.Time.UTC().Format(time.RFC3339),
+		})
+	}
+
+	if !request.Params.SkipDownload {
+		if hash, err := dirhash.HashZip(localPath, dirhash.Hash1); err == nil {
+			metadata = append(metadata, models.MetadataPair{
+				Name:  "h1",
+				Value: hash,
+			})
+		}
+	}
+
+	return metadata
+}
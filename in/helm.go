@@ -0,0 +1,89 @@
+package in
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// verifyHelmChart checks the downloaded chart against its digest in the index.yaml
+func (command *Command) verifyHelmChart(request Request, versionNumber string, localPath string) error {
+	chartVersion, err := findHelmChartVersion(command.nexusclient, request, versionNumber)
+	if err != nil {
+		return err
+	}
+
+	if chartVersion.Digest == "" {
+		return nil
+	}
+
+	digest, err := fileDigest(localPath, sha256.New())
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(digest) != chartVersion.Digest {
+		return fmt.Errorf("digest mismatch for %s: expected %s", localPath, chartVersion.Digest)
+	}
+
+	return nil
+}
+
+func (provider *MetadataProvider) helmSHA(request Request, remotePath string) string {
+	extraction, ok := versions.ExtractHelm(remotePath, request.Source.Helm)
+	if !ok {
+		return ""
+	}
+
+	chartVersion, err := findHelmChartVersion(provider.nexusClient, request, extraction.VersionNumber)
+	if err != nil {
+		return ""
+	}
+
+	return chartVersion.Digest
+}
+
+// helmMetadata returns the appVersion and description of the chart version
+func (command *Command) helmMetadata(request Request, versionNumber string) []models.MetadataPair {
+	chartVersion, err := findHelmChartVersion(command.nexusclient, request, versionNumber)
+	if err != nil {
+		return []models.MetadataPair{}
+	}
+
+	metadata := []models.MetadataPair{}
+
+	if chartVersion.AppVersion != "" {
+		metadata = append(metadata, models.MetadataPair{
+			Name:  "app_version",
+			Value: chartVersion.AppVersion,
+		})
+	}
+
+	if chartVersion.Description != "" {
+		metadata = append(metadata, models.MetadataPair{
+			Name:  "description",
+			Value: chartVersion.Description,
+		})
+	}
+
+	return metadata
+}
+
+func findHelmChartVersion(client nexusresource.NexusClient, request Request, versionNumber string) (models.HelmChartVersion, error) {
+	chartVersions, err := versions.GetHelmChartVersions(client, request.Source.Repository, request.Source.Helm)
+	if err != nil {
+		return models.HelmChartVersion{}, err
+	}
+
+	for _, chartVersion := range chartVersions {
+		if chartVersion.Version == versionNumber {
+			return chartVersion, nil
+		}
+	}
+
+	return models.HelmChartVersion{}, fmt.Errorf("version %s of helm chart %s not found in the index", versionNumber, request.Source.Helm.Chart)
+}
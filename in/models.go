@@ -13,6 +13,13 @@ type Request struct {
 type Params struct {
 	Unpack       bool `json:"unpack"`
 	SkipDownload bool `json:"skip_download"`
+
+	// Go modules only, the go.sum line to verify the module zip against
+	GoSum string `json:"go_sum"`
+
+	// Docker images only
+	SaveOCITarball bool   `json:"save_oci_tarball"`
+	Platform       string `json:"platform"`
 }
 
 // Response struct of the In command
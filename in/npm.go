@@ -0,0 +1,115 @@
+package in
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// verifyNpmTarball checks the downloaded tarball against the dist.integrity of
+// the package document, or the dist.shasum for packages published without it
+func (command *Command) verifyNpmTarball(request Request, versionNumber string, localPath string) error {
+	npmPackage, err := versions.GetNpmPackage(command.nexusclient, request.Source.Repository, request.Source.Npm)
+	if err != nil {
+		return err
+	}
+
+	packageVersion, ok := npmPackage.Versions[versionNumber]
+	if !ok {
+		return fmt.Errorf("version %s not found in npm package %s", versionNumber, request.Source.Npm.Package)
+	}
+
+	dist := packageVersion.Dist
+	if dist.Integrity != "" {
+		return verifyIntegrity(localPath, dist.Integrity)
+	}
+
+	if dist.Shasum != "" {
+		digest, err := fileDigest(localPath, sha1.New())
+		if err != nil {
+			return err
+		}
+
+		if hex.EncodeToString(digest) != dist.Shasum {
+			return fmt.Errorf("shasum mismatch for %s: expected %s", localPath, dist.Shasum)
+		}
+	}
+
+	return nil
+}
+
+func (provider *MetadataProvider) npmSHA(request Request, remotePath string) string {
+	extraction, ok := versions.ExtractNpm(remotePath, request.Source.Npm)
+	if !ok {
+		return ""
+	}
+
+	npmPackage, err := versions.GetNpmPackage(provider.nexusClient, request.Source.Repository, request.Source.Npm)
+	if err != nil {
+		return ""
+	}
+
+	return npmPackage.Versions[extraction.VersionNumber].Dist.Shasum
+}
+
+// verifyIntegrity checks a file against a Subresource Integrity string, any of
+// its space separated hashes matching is enough
+func verifyIntegrity(localPath string, integrity string) error {
+	for _, entry := range strings.Fields(integrity) {
+		algorithm, expected, found := strings.Cut(entry, "-")
+		if !found {
+			continue
+		}
+
+		var hasher hash.Hash
+		switch algorithm {
+		case "sha512":
+			hasher = sha512.New()
+		case "sha384":
+			hasher = sha512.New384()
+		case "sha256":
+			hasher = sha256.New()
+		case "sha1":
+			hasher = sha1.New()
+		default:
+			continue
+		}
+
+		digest, err := fileDigest(localPath, hasher)
+		if err != nil {
+			return err
+		}
+
+		// Options such as ?foo may follow the digest
+		expected, _, _ = strings.Cut(expected, "?")
+		if base64.StdEncoding.EncodeToString(digest) == expected {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("integrity mismatch for %s: expected %s", localPath, integrity)
+}
+
+func fileDigest(localPath string, hasher hash.Hash) ([]byte, error) {
+	localFile, err := os.Open(localPath)
+	if err != nil {
+		return nil, err
+	}
+	defer localFile.Close()
+
+	_, err = io.Copy(hasher, localFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher.Sum(nil), nil
+}
@@ -0,0 +1,34 @@
+package in
+
+import (
+	"strings"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// The flat container path is not the asset path Nexus stores the package
+// under, so the SHA is read from the component found by id and version
+func (provider *MetadataProvider) nugetSHA(request Request, remotePath string) string {
+	extraction, ok := versions.ExtractNuGet(remotePath, request.Source.NuGet)
+	if !ok {
+		return ""
+	}
+
+	items, err := provider.nexusClient.SearchComponents(request.Source.Repository, map[string]string{
+		"format":  models.FormatNuGet,
+		"name":    request.Source.NuGet.Package,
+		"version": extraction.VersionNumber,
+	})
+	if err != nil {
+		return ""
+	}
+
+	for _, item := range items {
+		if strings.EqualFold(item.Version, extraction.VersionNumber) && len(item.Assets) > 0 {
+			return item.Assets[0].Checksum.Sha1
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,58 @@
+package in
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// verifyPypiFile checks the downloaded distribution against the #sha256=
+// fragment of its link in the simple index
+func (command *Command) verifyPypiFile(request Request, remotePath string, localPath string) error {
+	file, err := findPypiFile(command.nexusclient, request, remotePath)
+	if err != nil {
+		return err
+	}
+
+	if file.Sha256 == "" {
+		return nil
+	}
+
+	digest, err := fileDigest(localPath, sha256.New())
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(digest) != file.Sha256 {
+		return fmt.Errorf("sha256 mismatch for %s: expected %s", localPath, file.Sha256)
+	}
+
+	return nil
+}
+
+func (provider *MetadataProvider) pypiSHA(request Request, remotePath string) string {
+	file, err := findPypiFile(provider.nexusClient, request, remotePath)
+	if err != nil {
+		return ""
+	}
+
+	return file.Sha256
+}
+
+func findPypiFile(client nexusresource.NexusClient, request Request, remotePath string) (versions.PypiFile, error) {
+	files, err := versions.GetPypiFiles(client, request.Source.Repository, request.Source.Pypi)
+	if err != nil {
+		return versions.PypiFile{}, err
+	}
+
+	for _, file := range files {
+		if file.Path == remotePath {
+			return file, nil
+		}
+	}
+
+	return versions.PypiFile{}, fmt.Errorf("%s not found in the index of pypi package %s", remotePath, request.Source.Pypi.Package)
+}
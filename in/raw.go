@@ -0,0 +1,55 @@
+package in
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// verifyRawAsset checks the asset still has the content of the version when
+// redeploys are tracked, a redeploy since check would otherwise be fetched
+// under the same version
+func (command *Command) verifyRawAsset(request Request, remotePath string) error {
+	if request.Version.Sha256 == "" && request.Version.LastModified == "" {
+		return nil
+	}
+
+	asset, err := versions.FindAsset(command.nexusclient, request.Source.Repository, remotePath)
+	if err != nil {
+		return err
+	}
+
+	if request.Version.Sha256 != "" && asset.Checksum.Sha256 != request.Version.Sha256 {
+		return fmt.Errorf("%s was redeployed since the version was checked: sha256 is %s, expected %s", remotePath, asset.Checksum.Sha256, request.Version.Sha256)
+	}
+
+	if request.Version.LastModified != "" {
+		lastModified := asset.UploadTime().UTC().Format(time.RFC3339Nano)
+		if lastModified != request.Version.LastModified {
+			return fmt.Errorf("%s was redeployed since the version was checked: last modified %s, expected %s", remotePath, lastModified, request.Version.LastModified)
+		}
+	}
+
+	return nil
+}
+
+// verifyRawFile checks the downloaded file against the sha256 of the version
+func (command *Command) verifyRawFile(request Request, localPath string) error {
+	if request.Version.Sha256 == "" {
+		return nil
+	}
+
+	digest, err := fileDigest(localPath, sha256.New())
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(digest) != request.Version.Sha256 {
+		return fmt.Errorf("sha256 mismatch for %s: expected %s", localPath, request.Version.Sha256)
+	}
+
+	return nil
+}
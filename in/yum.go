@@ -0,0 +1,59 @@
+package in
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// verifyYumPackage checks the downloaded .rpm against its sha256 checksum in
+// the primary metadata
+func (command *Command) verifyYumPackage(request Request, remotePath string, localPath string) error {
+	yumPackage, err := findYumPackage(command.nexusclient, request, remotePath)
+	if err != nil {
+		return err
+	}
+
+	if yumPackage.Checksum.Type != "sha256" {
+		return nil
+	}
+
+	digest, err := fileDigest(localPath, sha256.New())
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(digest) != yumPackage.Checksum.Value {
+		return fmt.Errorf("sha256 mismatch for %s: expected %s", localPath, yumPackage.Checksum.Value)
+	}
+
+	return nil
+}
+
+func (provider *MetadataProvider) yumSHA(request Request, remotePath string) string {
+	yumPackage, err := findYumPackage(provider.nexusClient, request, remotePath)
+	if err != nil {
+		return ""
+	}
+
+	return yumPackage.Checksum.Value
+}
+
+func findYumPackage(client nexusresource.NexusClient, request Request, remotePath string) (models.YumPackage, error) {
+	packages, err := versions.GetYumPackages(client, request.Source.Repository, request.Source.Yum)
+	if err != nil {
+		return models.YumPackage{}, err
+	}
+
+	for _, yumPackage := range packages {
+		if yumPackage.Location.Href == remotePath {
+			return yumPackage, nil
+		}
+	}
+
+	return models.YumPackage{}, fmt.Errorf("%s not found in the repodata of yum package %s", remotePath, request.Source.Yum.Package)
+}
@@ -0,0 +1,10 @@
+package models
+
+// AptPackage struct represent a stanza of a Packages index
+type AptPackage struct {
+	Package      string
+	Version      string
+	Architecture string
+	Filename     string
+	SHA256       string
+}
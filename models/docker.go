@@ -0,0 +1,62 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Media types of the manifests and indexes of Docker and OCI images
+const (
+	DockerManifestMediaType     = "application/vnd.docker.distribution.manifest.v2+json"
+	DockerManifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+	OCIImageManifestMediaType   = "application/vnd.oci.image.manifest.v1+json"
+	OCIImageIndexMediaType      = "application/vnd.oci.image.index.v1+json"
+)
+
+// DockerDigest returns the sha256 digest of a content in the registry notation
+func DockerDigest(content []byte) string {
+	digest := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(digest[:])
+}
+
+// DockerTagList struct represent the tags list of an image in a registry
+type DockerTagList struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// DockerToken struct represent the response of a registry token endpoint
+type DockerToken struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+}
+
+// DockerDescriptor struct represent a content descriptor of an image
+type DockerDescriptor struct {
+	MediaType   string            `json:"mediaType,omitempty"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Platform    *DockerPlatform   `json:"platform,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DockerPlatform struct represent the platform of a manifest in an index
+type DockerPlatform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// DockerManifest struct represent an image manifest or an index of manifests
+type DockerManifest struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	MediaType     string             `json:"mediaType,omitempty"`
+	Config        *DockerDescriptor  `json:"config,omitempty"`
+	Layers        []DockerDescriptor `json:"layers,omitempty"`
+	Manifests     []DockerDescriptor `json:"manifests,omitempty"`
+}
+
+// IsIndex returns true when the manifest is an index of platform manifests
+func (manifest DockerManifest) IsIndex() bool {
+	return manifest.MediaType == OCIImageIndexMediaType || manifest.MediaType == DockerManifestListMediaType || manifest.Manifests != nil
+}
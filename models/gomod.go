@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// GoModuleInfo struct represent the .info of a module version in a GOPROXY
+type GoModuleInfo struct {
+	Version string    `json:"Version"`
+	Time    time.Time `json:"Time"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// HelmIndex struct represent the index.yaml of a Helm repository
+type HelmIndex struct {
+	APIVersion string                        `yaml:"apiVersion"`
+	Entries    map[string][]HelmChartVersion `yaml:"entries"`
+}
+
+// HelmChartVersion struct represent a chart version, as listed in the index.yaml
+// or declared by the Chart.yaml of a packaged chart
+type HelmChartVersion struct {
+	Name        string   `yaml:"name"`
+	Version     string   `yaml:"version"`
+	AppVersion  string   `yaml:"appVersion"`
+	Description string   `yaml:"description"`
+	Digest      string   `yaml:"digest"`
+	URLs        []string `yaml:"urls"`
+}
@@ -0,0 +1,48 @@
+package models
+
+// MavenMetadata struct represent a maven-metadata.xml document
+type MavenMetadata struct {
+	GroupID    string                  `xml:"groupId"`
+	ArtifactID string                  `xml:"artifactId"`
+	Version    string                  `xml:"version"`
+	Versioning MavenMetadataVersioning `xml:"versioning"`
+}
+
+// MavenMetadataVersioning struct represent the versioning section of a maven-metadata.xml
+type MavenMetadataVersioning struct {
+	Latest           string                 `xml:"latest"`
+	Release          string                 `xml:"release"`
+	Versions         []string               `xml:"versions>version"`
+	Snapshot         MavenSnapshot          `xml:"snapshot"`
+	SnapshotVersions []MavenSnapshotVersion `xml:"snapshotVersions>snapshotVersion"`
+	LastUpdated      string                 `xml:"lastUpdated"`
+}
+
+// MavenSnapshot struct represent the latest snapshot build of a maven-metadata.xml
+type MavenSnapshot struct {
+	Timestamp   string `xml:"timestamp"`
+	BuildNumber int    `xml:"buildNumber"`
+}
+
+// MavenSnapshotVersion struct represent a timestamped snapshot build of a maven-metadata.xml
+type MavenSnapshotVersion struct {
+	Classifier string `xml:"classifier"`
+	Extension  string `xml:"extension"`
+	Value      string `xml:"value"`
+	Updated    string `xml:"updated"`
+}
+
+// MavenPom struct represent the coordinates declared in a pom.xml
+type MavenPom struct {
+	GroupID    string         `xml:"groupId"`
+	ArtifactID string         `xml:"artifactId"`
+	Version    string         `xml:"version"`
+	Packaging  string         `xml:"packaging"`
+	Parent     MavenPomParent `xml:"parent"`
+}
+
+// MavenPomParent struct represent the parent coordinates declared in a pom.xml
+type MavenPomParent struct {
+	GroupID string `xml:"groupId"`
+	Version string `xml:"version"`
+}
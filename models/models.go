@@ -1,51 +1,623 @@
 package models
 
-import "strings"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bmatcuk/doublestar/v4"
+	"golang.org/x/mod/module"
+)
+
+// Repository formats supported by the resource
+const (
+	FormatRaw    = "raw"
+	FormatMaven2 = "maven2"
+	FormatNpm    = "npm"
+	FormatPypi   = "pypi"
+	FormatHelm   = "helm"
+	FormatDocker = "docker"
+	FormatNuGet  = "nuget"
+	FormatGo     = "go"
+	FormatApt    = "apt"
+	FormatYum    = "yum"
+)
+
+// Formats lists the repository formats supported by the resource
+var Formats = []string{
+	FormatRaw,
+	FormatMaven2,
+	FormatNpm,
+	FormatPypi,
+	FormatHelm,
+	FormatDocker,
+	FormatNuGet,
+	FormatGo,
+	FormatApt,
+	FormatYum,
+}
+
+// Version schemes of the versions extracted with the regexp
+const (
+	VersionSchemeSemver    = "semver"
+	VersionSchemeCalver    = "calver"
+	VersionSchemeInteger   = "integer"
+	VersionSchemeTimestamp = "timestamp"
+	VersionSchemeLexical   = "lexical"
+)
+
+// VersionSchemes lists the version schemes supported by the resource, the
+// versions are semi-semantic when the scheme is omitted
+var VersionSchemes = []string{
+	VersionSchemeSemver,
+	VersionSchemeCalver,
+	VersionSchemeInteger,
+	VersionSchemeTimestamp,
+	VersionSchemeLexical,
+}
+
+// Handling of the pre-releases of the semver version scheme
+const (
+	PreReleaseInclude = "include"
+	PreReleaseExclude = "exclude"
+	PreReleaseOnly    = "only"
+)
+
+// Orderings of the versions emitted by check
+const (
+	OrderByVersion      = "version"
+	OrderByLastModified = "last_modified"
+)
+
+// Content included in the versions to detect redeploys of an artifact
+const (
+	TrackRedeploysSha256       = "sha256"
+	TrackRedeploysLastModified = "last_modified"
+)
+
+// ReservedCaptureNames lists the names the named groups of the regexp can't
+// have, as in writes files or metadata with these names
+var ReservedCaptureNames = []string{"filename", "url", "sha", "path"}
+
+// Identities of the versions emitted by check, the path of the artifact or its
+// version number
+const (
+	VersionIdentityPath    = "path"
+	VersionIdentityVersion = "version"
+)
+
+// Types of repositories
+const (
+	RepositoryTypeHosted = "hosted"
+	RepositoryTypeProxy  = "proxy"
+	RepositoryTypeGroup  = "group"
+)
 
 // Source Struct for the Nexus Resource
 type Source struct {
-	URL        string `json:"url"`
-	Repository string `json:"repository"`
-	Username   string `json:"username"`
-	Password   string `json:"password"`
-	Group      string `json:"group"`
-	Regexp     string `json:"regexp"`
-	Timeout    int    `json:"timeout"`
-	Debug      bool   `json:"debug"`
+	URL        string       `json:"url"`
+	Repository string       `json:"repository"`
+	Username   string       `json:"username"`
+	Password   string       `json:"password"`
+	Group      string       `json:"group"`
+	Regexp     string       `json:"regexp"`
+	Timeout    int          `json:"timeout"`
+	Debug      bool         `json:"debug"`
+	Format     string       `json:"format"`
+	Maven      MavenSource  `json:"maven"`
+	Npm        NpmSource    `json:"npm"`
+	Pypi       PypiSource   `json:"pypi"`
+	Helm       HelmSource   `json:"helm"`
+	Docker     DockerSource `json:"docker"`
+	NuGet      NuGetSource  `json:"nuget"`
+	Go         GoSource     `json:"go"`
+	Apt        AptSource    `json:"apt"`
+	Yum        YumSource    `json:"yum"`
+
+	// Semver range the versions must satisfy, e.g. ">=2.3.0 <3.0.0"
+	VersionConstraint string `json:"version_constraint"`
+
+	// Scheme of the versions extracted with the regexp, semi-semantic by default
+	VersionScheme string `json:"version_scheme"`
+
+	// Go reference time layout of the versions of the timestamp version scheme
+	VersionLayout string `json:"version_layout"`
+
+	// Prefix stripped from the versions before they are parsed, e.g. "v"
+	VersionPrefix string `json:"version_prefix"`
+
+	// Pre-releases of the semver version scheme to include, exclude, or only
+	// those with the listed identifiers
+	PreRelease            string   `json:"pre_release"`
+	PreReleaseIdentifiers []string `json:"pre_release_identifiers"`
+
+	// Order of the versions, by version or by upload time of the assets
+	OrderBy string `json:"order_by"`
+
+	// Content of the asset included in the versions, so a redeploy of the same
+	// path is a new version
+	TrackRedeploys string `json:"track_redeploys"`
+
+	// Lowest version emitted by check, all the versions from it are emitted
+	// by the first check
+	InitialVersion string `json:"initial_version"`
+
+	// Maximum number of versions emitted by check, the newest are kept
+	MaxVersions int `json:"max_versions"`
+
+	// Named groups of the regexp the versions are ordered by, e.g. version
+	// then build
+	SortKeys []string `json:"sort_keys"`
+
+	// Fail check when a path matched by the regexp has a version which can't
+	// be parsed, instead of skipping it
+	Strict bool `json:"strict"`
+
+	// Groups and regexps of a raw repository whose versions are merged, in
+	// place of group and regexp
+	Selectors []Selector `json:"selectors"`
+
+	// Paths matched by the regexp which are excluded from the versions, e.g.
+	// debug builds
+	IgnoreRegexps []string `json:"ignore_regexps"`
+	IgnoreGlobs   []string `json:"ignore_globs"`
+
+	// Identity of the versions, the version number keeps it when artifacts are
+	// moved to another group
+	VersionIdentity string `json:"version_identity"`
+
+	// Window of the upload time of the assets of the versions emitted by check,
+	// as durations e.g. "10m", so uploads still in progress are skipped
+	MinAge string `json:"min_age"`
+	MaxAge string `json:"max_age"`
+
+	// Skip the assets Nexus has no sha256 checksum for yet
+	RequireChecksum bool `json:"require_checksum"`
+}
+
+// UsesAssets reports whether the versions of a raw repository are searched
+// with their assets, for their upload time or checksum
+func (source Source) UsesAssets() bool {
+	return source.OrderBy == OrderByLastModified || source.TrackRedeploys != "" ||
+		source.MinAge != "" || source.MaxAge != "" || source.RequireChecksum
+}
+
+// Selector struct holds a group and the regexp matching the paths of its
+// versions
+type Selector struct {
+	Group  string `json:"group"`
+	Regexp string `json:"regexp"`
+}
+
+// SelectorList returns the selectors of the Source, or its group and regexp
+// when there are none
+func (source Source) SelectorList() []Selector {
+	if len(source.Selectors) > 0 {
+		return source.Selectors
+	}
+
+	return []Selector{{Group: source.Group, Regexp: source.Regexp}}
+}
+
+// WithSelector returns a copy of the Source with the group and regexp of a
+// selector
+func (source Source) WithSelector(selector Selector) Source {
+	source.Group = selector.Group
+	source.Regexp = selector.Regexp
+	source.Selectors = nil
+	return source
+}
+
+// MavenSource struct holds the coordinates of a Maven artifact
+type MavenSource struct {
+	GroupID    string `json:"group_id"`
+	ArtifactID string `json:"artifact_id"`
+	Version    string `json:"version"`
+	Extension  string `json:"extension"`
+	Classifier string `json:"classifier"`
+}
+
+// FileExtension returns the configured extension, defaulting to jar
+func (maven MavenSource) FileExtension() string {
+	if maven.Extension == "" {
+		return "jar"
+	}
+
+	return maven.Extension
+}
+
+// IsSnapshot returns true when the configured version is a Maven SNAPSHOT
+func (maven MavenSource) IsSnapshot() bool {
+	return strings.HasSuffix(maven.Version, "-SNAPSHOT")
+}
+
+// NpmSource struct holds the npm package to follow
+type NpmSource struct {
+	Package string `json:"package"`
+	DistTag string `json:"dist_tag"`
+}
+
+// PypiSource struct holds the Python package to follow and the distribution to fetch
+type PypiSource struct {
+	Package     string `json:"package"`
+	PythonTag   string `json:"python_tag"`
+	Platform    string `json:"platform"`
+	PackageType string `json:"package_type"`
+}
+
+// HelmSource struct holds the Helm chart to follow
+type HelmSource struct {
+	Chart      string `json:"chart"`
+	AppVersion string `json:"app_version"`
+}
+
+// DockerSource struct holds the Docker image to follow
+type DockerSource struct {
+	Image       string `json:"image"`
+	RegistryURL string `json:"registry_url"`
+	SearchAPI   bool   `json:"search_api"`
+}
+
+// NuGetSource struct holds the NuGet package to follow
+type NuGetSource struct {
+	Package string `json:"package"`
+}
+
+// GoSource struct holds the Go module to follow
+type GoSource struct {
+	Module         string `json:"module"`
+	PseudoVersions bool   `json:"pseudo_versions"`
+}
+
+// AptSource struct holds the Debian package to follow and the Packages index listing it
+type AptSource struct {
+	Package      string `json:"package"`
+	Distribution string `json:"distribution"`
+	Component    string `json:"component"`
+	Architecture string `json:"architecture"`
+}
+
+// PackagesPath returns the path of the Packages index of the distribution,
+// component and architecture, defaulting to main and amd64
+func (apt AptSource) PackagesPath() string {
+	component := apt.Component
+	if component == "" {
+		component = "main"
+	}
+
+	architecture := apt.Architecture
+	if architecture == "" {
+		architecture = "amd64"
+	}
+
+	return "dists/" + apt.Distribution + "/" + component + "/binary-" + architecture + "/Packages"
+}
+
+// YumSource struct holds the RPM package to follow and the directory of its repodata
+type YumSource struct {
+	Package      string `json:"package"`
+	Architecture string `json:"architecture"`
+	Directory    string `json:"directory"`
+}
+
+// DockerRegistryURL returns the base URL of the registry v2 API, by default
+// the path of the repository on the Nexus server
+func (source Source) DockerRegistryURL() string {
+	if source.Docker.RegistryURL != "" {
+		return source.Docker.RegistryURL
+	}
+
+	return strings.TrimSuffix(source.URL, "/") + "/repository/" + source.Repository
 }
 
 // IsValid validates the provided Source
 func (source Source) IsValid() (bool, string) {
+	var problems []string
+
 	if source.URL == "" {
-		return false, "url must be specified"
+		problems = append(problems, "url must be specified")
 	}
 
 	if source.Repository == "" {
-		return false, "repository must be specified"
+		problems = append(problems, "repository must be specified")
 	}
 
 	if source.Username == "" {
-		return false, "username must be specified"
+		problems = append(problems, "username must be specified")
 	}
 
 	if source.Password == "" {
-		return false, "password must be specified"
+		problems = append(problems, "password must be specified")
+	}
+
+	if len(source.Selectors) > 0 {
+		if source.Group != "" || source.Regexp != "" {
+			problems = append(problems, "selectors can't be used with group and regexp")
+		}
+
+		if source.Format != "" && source.Format != FormatRaw {
+			problems = append(problems, "selectors are only supported for raw repositories")
+		}
+
+		for i, selector := range source.Selectors {
+			field := fmt.Sprintf("selectors[%d].", i)
+			if selector.Regexp == "" {
+				problems = append(problems, field+"regexp must be specified")
+			}
+
+			problems = append(problems, source.WithSelector(selector).selectorProblems(field)...)
+		}
+	} else {
+		problems = append(problems, source.selectorProblems("")...)
 	}
 
+	if source.VersionConstraint != "" {
+		if _, err := semver.NewConstraint(source.VersionConstraint); err != nil {
+			problems = append(problems, fmt.Sprintf("version_constraint is not valid: %s", err))
+		}
+	}
+
+	if source.VersionScheme != "" && !containsString(VersionSchemes, source.VersionScheme) {
+		problems = append(problems, fmt.Sprintf("version_scheme '%s' is not supported", source.VersionScheme))
+	}
+
+	if source.VersionScheme == VersionSchemeTimestamp && source.VersionLayout == "" {
+		problems = append(problems, "version_layout must be specified with version_scheme 'timestamp'")
+	}
+
+	if source.VersionScheme != VersionSchemeTimestamp && source.VersionLayout != "" {
+		problems = append(problems, "version_layout can only be used with version_scheme 'timestamp'")
+	}
+
+	switch source.PreRelease {
+	case "", PreReleaseInclude, PreReleaseExclude:
+		if len(source.PreReleaseIdentifiers) > 0 {
+			problems = append(problems, "pre_release_identifiers can only be used with pre_release 'only'")
+		}
+	case PreReleaseOnly:
+		if len(source.PreReleaseIdentifiers) == 0 {
+			problems = append(problems, "pre_release_identifiers must be specified with pre_release 'only'")
+		}
+	default:
+		problems = append(problems, "pre_release must be one of 'include', 'exclude' or 'only'")
+	}
+
+	if source.PreRelease != "" && source.VersionScheme != VersionSchemeSemver {
+		problems = append(problems, "pre_release requires version_scheme 'semver'")
+	}
+
+	for i, pattern := range source.IgnoreRegexps {
+		if _, err := regexp.Compile(pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("ignore_regexps[%d] is not valid: %s", i, err))
+		}
+	}
+
+	for i, glob := range source.IgnoreGlobs {
+		if !doublestar.ValidatePattern(glob) {
+			problems = append(problems, fmt.Sprintf("ignore_globs[%d] is not a valid glob pattern", i))
+		}
+	}
+
+	if source.MaxVersions < 0 {
+		problems = append(problems, "max_versions must not be negative")
+	}
+
+	switch source.OrderBy {
+	case "", OrderByVersion:
+	case OrderByLastModified:
+		if source.Format != "" && source.Format != FormatRaw {
+			problems = append(problems, "order_by 'last_modified' is only supported for raw repositories")
+		}
+	default:
+		problems = append(problems, "order_by must be one of 'version' or 'last_modified'")
+	}
+
+	switch source.TrackRedeploys {
+	case "":
+	case TrackRedeploysSha256, TrackRedeploysLastModified:
+		if source.Format != "" && source.Format != FormatRaw {
+			problems = append(problems, "track_redeploys is only supported for raw repositories")
+		}
+	default:
+		problems = append(problems, "track_redeploys must be one of 'sha256' or 'last_modified'")
+	}
+
+	if source.MinAge != "" && !isDuration(source.MinAge) {
+		problems = append(problems, "min_age must be a positive duration, e.g. '10m'")
+	}
+
+	if source.MaxAge != "" && !isDuration(source.MaxAge) {
+		problems = append(problems, "max_age must be a positive duration, e.g. '720h'")
+	}
+
+	if (source.MinAge != "" || source.MaxAge != "" || source.RequireChecksum) && source.Format != "" && source.Format != FormatRaw {
+		problems = append(problems, "min_age, max_age and require_checksum are only supported for raw repositories")
+	}
+
+	switch source.VersionIdentity {
+	case "", VersionIdentityPath:
+	case VersionIdentityVersion:
+		if source.Format != "" && source.Format != FormatRaw {
+			problems = append(problems, "version_identity 'version' is only supported for raw repositories")
+		}
+	default:
+		problems = append(problems, "version_identity must be one of 'path' or 'version'")
+	}
+
+	switch source.Format {
+	case "", FormatRaw:
+	case FormatMaven2:
+		if source.Maven.GroupID == "" {
+			problems = append(problems, "maven.group_id must be specified")
+		}
+
+		if source.Maven.ArtifactID == "" {
+			problems = append(problems, "maven.artifact_id must be specified")
+		}
+
+		if source.Maven.Version != "" && !source.Maven.IsSnapshot() {
+			problems = append(problems, "maven.version must be a -SNAPSHOT version")
+		}
+	case FormatNpm:
+		if source.Npm.Package == "" {
+			problems = append(problems, "npm.package must be specified")
+		}
+	case FormatPypi:
+		if source.Pypi.Package == "" {
+			problems = append(problems, "pypi.package must be specified")
+		}
+
+		if source.Pypi.PackageType != "" && source.Pypi.PackageType != "wheel" && source.Pypi.PackageType != "sdist" {
+			problems = append(problems, "pypi.package_type must be one of 'wheel' or 'sdist'")
+		}
+	case FormatHelm:
+		if source.Helm.Chart == "" {
+			problems = append(problems, "helm.chart must be specified")
+		}
+
+		if _, err := regexp.Compile(source.Helm.AppVersion); err != nil {
+			problems = append(problems, fmt.Sprintf("helm.app_version is not a valid regexp: %s", err))
+		}
+	case FormatDocker:
+		if source.Docker.Image == "" {
+			problems = append(problems, "docker.image must be specified")
+		}
+
+		if source.Regexp == "" {
+			problems = append(problems, "regexp must be specified to match the tags")
+		}
+	case FormatNuGet:
+		if source.NuGet.Package == "" {
+			problems = append(problems, "nuget.package must be specified")
+		}
+	case FormatGo:
+		if source.Go.Module == "" {
+			problems = append(problems, "go.module must be specified")
+		}
+
+		if _, err := module.EscapePath(source.Go.Module); err != nil {
+			problems = append(problems, fmt.Sprintf("go.module is not a valid module path: %s", err))
+		}
+	case FormatApt:
+		if source.Apt.Package == "" {
+			problems = append(problems, "apt.package must be specified")
+		}
+
+		if source.Apt.Distribution == "" {
+			problems = append(problems, "apt.distribution must be specified")
+		}
+	case FormatYum:
+		if source.Yum.Package == "" {
+			problems = append(problems, "yum.package must be specified")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("format '%s' is not supported", source.Format))
+	}
+
+	if len(problems) > 0 {
+		return false, strings.Join(problems, "; ")
+	}
+
+	return true, ""
+}
+
+// selectorProblems returns the problems of the group and regexp of the
+// Source, the field names prefixed with the field of their selector
+func (source Source) selectorProblems(field string) []string {
+	var problems []string
+
 	if source.Group != "" && !strings.HasPrefix(source.Group, "/") {
-		return false, "group must start with '/'"
+		problems = append(problems, field+"group must start with '/'")
+	}
+
+	if !doublestar.ValidatePattern(source.Group) {
+		problems = append(problems, field+"group is not a valid glob pattern")
 	}
 
 	if source.Regexp != "" && strings.HasPrefix(source.Regexp, "/") {
-		return false, "regexp should not start with '/'"
+		problems = append(problems, field+"regexp should not start with '/'")
 	}
 
-	return true, ""
+	compiled, err := regexp.Compile(source.Regexp)
+	if err != nil {
+		return append(problems, fmt.Sprintf("%sregexp is not valid: %s", field, err))
+	}
+
+	if source.Regexp != "" && compiled.NumSubexp() == 0 {
+		problems = append(problems, field+"regexp must have a capture group for the version")
+	}
+
+	if problem := source.checkGroupPrefix(compiled); problem != "" {
+		problems = append(problems, field+problem)
+	}
+
+	for _, name := range compiled.SubexpNames() {
+		if containsString(ReservedCaptureNames, name) {
+			problems = append(problems, fmt.Sprintf("%sregexp group '%s' is reserved for a file or metadata of in", field, name))
+		}
+	}
+
+	for _, key := range source.SortKeys {
+		if key != "version" && !containsString(compiled.SubexpNames(), key) {
+			problems = append(problems, fmt.Sprintf("sort_keys '%s' is not a named group of %sregexp", key, field))
+		}
+	}
+
+	return problems
+}
+
+// checkGroupPrefix reports a regexp whose literal prefix can't match the
+// paths under the group, e.g. group '/builds' with regexp 'releases/app-(.*)'.
+func (source Source) checkGroupPrefix(compiled *regexp.Regexp) string {
+	if source.Format != "" && source.Format != FormatRaw || strings.HasPrefix(source.Regexp, "/") {
+		return ""
+	}
+
+	group := strings.Trim(source.Group, "/")
+	if index := strings.IndexAny(group, "*?[{"); index >= 0 {
+		group = group[:index]
+	} else if group != "" {
+		group += "/"
+	}
+
+	prefix, _ := compiled.LiteralPrefix()
+	if strings.HasPrefix(prefix, group) || strings.HasPrefix(group, prefix) {
+		return ""
+	}
+
+	return fmt.Sprintf("regexp must match paths in group '%s', but starts with '%s'", source.Group, prefix)
+}
+
+// isDuration reports whether a value is a positive duration
+func isDuration(value string) bool {
+	duration, err := time.ParseDuration(value)
+	return err == nil && duration > 0
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, element := range haystack {
+		if element == needle {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Version struct
 type Version struct {
-	Path string `json:"path,omitempty"`
+	Path   string `json:"path,omitempty"`
+	GAV    string `json:"gav,omitempty"`
+	Digest string `json:"digest,omitempty"`
+
+	// Version number of an artifact when it is the identity of the version
+	VersionNumber string `json:"version_number,omitempty"`
+
+	// Content of a raw asset when redeploys are tracked
+	Sha256       string `json:"sha256,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
 }
 
 // MetadataPair struct
@@ -60,19 +632,42 @@ type RespositoryItems struct {
 	ContinuationToken string           `json:"continuationToken"`
 }
 
+// Repository struct represent a repository of the Nexus server
+type Repository struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	Type   string `json:"type"`
+	URL    string `json:"url"`
+}
+
 // RepositoryItem struct represent a Component in Nexus
 type RepositoryItem struct {
-	ID     string                `json:"id"`
-	Group  string                `json:"group"`
-	Name   string                `json:"name"`
-	Assets []RepositoryItemAsset `json:"assets"`
+	ID      string                `json:"id"`
+	Group   string                `json:"group"`
+	Name    string                `json:"name"`
+	Version string                `json:"version"`
+	Format  string                `json:"format"`
+	Assets  []RepositoryItemAsset `json:"assets"`
 }
 
 // RepositoryItemAsset struct represent an Asset in Nexus
 type RepositoryItemAsset struct {
-	DownloadURL string                       `json:"downloadUrl"`
-	ID          string                       `json:"id"`
-	Checksum    RepositoryItemAssetsChecksum `json:"checksum"`
+	DownloadURL  string                       `json:"downloadUrl"`
+	Path         string                       `json:"path"`
+	ID           string                       `json:"id"`
+	Checksum     RepositoryItemAssetsChecksum `json:"checksum"`
+	LastModified time.Time                    `json:"lastModified"`
+	BlobCreated  time.Time                    `json:"blobCreated"`
+}
+
+// UploadTime returns when the asset was last uploaded, its last modification
+// or else the creation of its blob
+func (asset RepositoryItemAsset) UploadTime() time.Time {
+	if !asset.LastModified.IsZero() {
+		return asset.LastModified
+	}
+
+	return asset.BlobCreated
 }
 
 // RepositoryItemAssetsChecksum struct represent an Assets Checksum in Nexus
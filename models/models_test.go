@@ -113,6 +113,43 @@ var _ = Describe("Models", func() {
 				Ω(err).Should(Equal("group must start with '/'"))
 			})
 
+			It("validates invalid Group pattern", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Group:      "/releases/[1-",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("group is not a valid glob pattern"))
+			})
+
+			It("validates selectors", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Selectors: []models.Selector{
+						{Group: "/legacy/app", Regexp: "legacy/app/app_(.*).tar.gz"},
+						{Group: "app/releases"},
+					},
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("selectors[1].regexp must be specified; selectors[1].group must start with '/'"))
+
+				source.Selectors[1] = models.Selector{Group: "/app/releases", Regexp: "app/releases/app-(.*).tgz"}
+				source.Regexp = "app-(.*).tgz"
+				ok, err = source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("selectors can't be used with group and regexp"))
+			})
+
 			It("validates invalid Regex", func() {
 				var source = models.Source{
 					URL:        "http://nexus-url.com",
@@ -127,6 +164,487 @@ var _ = Describe("Models", func() {
 				Ω(ok).Should(BeFalse())
 				Ω(err).Should(Equal("regexp should not start with '/'"))
 			})
+
+			It("validates regexp which doesn't compile", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Regexp:     "a-(.*.tgz",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(HavePrefix("regexp is not valid: "))
+			})
+
+			It("validates regexp without a capture group", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Regexp:     "a-.*.tgz",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("regexp must have a capture group for the version"))
+			})
+
+			It("validates regexp which can't match paths in the group", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Group:      "/builds",
+					Username:   "user",
+					Password:   "password",
+					Regexp:     "releases/a-(.*).tgz",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("regexp must match paths in group '/builds', but starts with 'releases/a-'"))
+
+				source.Group = "/rel*"
+				ok, _ = source.IsValid()
+				Ω(ok).Should(BeTrue())
+			})
+
+			It("reports all the problems", func() {
+				var source = models.Source{
+					URL:         "http://nexus-url.com",
+					Repository:  "repository-name",
+					Regexp:      "a-.*.tgz",
+					MaxVersions: -1,
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("username must be specified; password must be specified; regexp must have a capture group for the version; max_versions must not be negative"))
+			})
+
+			It("validates unsupported Format", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Format:     "bower",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("format 'bower' is not supported"))
+			})
+
+			It("validates missing Maven coordinates", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Format:     models.FormatMaven2,
+					Maven: models.MavenSource{
+						GroupID: "com.example",
+					},
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("maven.artifact_id must be specified"))
+			})
+
+			It("validates missing npm package", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Format:     models.FormatNpm,
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("npm.package must be specified"))
+			})
+
+			It("validates invalid pypi package type", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Format:     models.FormatPypi,
+					Pypi: models.PypiSource{
+						Package:     "friendly-bard",
+						PackageType: "egg",
+					},
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("pypi.package_type must be one of 'wheel' or 'sdist'"))
+			})
+
+			It("validates invalid go module path", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Format:     models.FormatGo,
+					Go: models.GoSource{
+						Module: "github.com/contoso/billing/",
+					},
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(HavePrefix("go.module is not a valid module path"))
+			})
+
+			It("validates invalid version constraint", func() {
+				var source = models.Source{
+					URL:               "http://nexus-url.com",
+					Repository:        "repository-name",
+					Username:          "user",
+					Password:          "password",
+					VersionConstraint: ">=2.3.0 <<3",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(HavePrefix("version_constraint is not valid"))
+			})
+
+			It("validates unsupported order by", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					OrderBy:    "name",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("order_by must be one of 'version' or 'last_modified'"))
+			})
+
+			It("validates order by last modification of other formats", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Format:     models.FormatNpm,
+					Npm:        models.NpmSource{Package: "billing"},
+					OrderBy:    models.OrderByLastModified,
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("order_by 'last_modified' is only supported for raw repositories"))
+			})
+
+			It("validates unsupported track redeploys", func() {
+				var source = models.Source{
+					URL:            "http://nexus-url.com",
+					Repository:     "repository-name",
+					Username:       "user",
+					Password:       "password",
+					TrackRedeploys: "md5",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("track_redeploys must be one of 'sha256' or 'last_modified'"))
+			})
+
+			It("validates invalid ignore regexps and globs", func() {
+				var source = models.Source{
+					URL:           "http://nexus-url.com",
+					Repository:    "repository-name",
+					Username:      "user",
+					Password:      "password",
+					IgnoreRegexps: []string{`.*-debug\.tgz`, "tmp/(.*"},
+					IgnoreGlobs:   []string{"**/tmp/[a-"},
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(HavePrefix("ignore_regexps[1] is not valid: "))
+				Ω(err).Should(HaveSuffix("; ignore_globs[0] is not a valid glob pattern"))
+			})
+
+			It("validates unsupported version identity", func() {
+				var source = models.Source{
+					URL:             "http://nexus-url.com",
+					Repository:      "repository-name",
+					Username:        "user",
+					Password:        "password",
+					VersionIdentity: "gav",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("version_identity must be one of 'path' or 'version'"))
+
+				source.VersionIdentity = models.VersionIdentityVersion
+				source.Format = models.FormatNpm
+				source.Npm.Package = "app"
+				ok, err = source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("version_identity 'version' is only supported for raw repositories"))
+			})
+
+			It("validates invalid age window", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					MinAge:     "10",
+					MaxAge:     "-1h",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("min_age must be a positive duration, e.g. '10m'; max_age must be a positive duration, e.g. '720h'"))
+			})
+
+			It("validates zero age window", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					MinAge:     "0s",
+					MaxAge:     "0",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("min_age must be a positive duration, e.g. '10m'; max_age must be a positive duration, e.g. '720h'"))
+			})
+
+			It("validates negative max versions", func() {
+				var source = models.Source{
+					URL:         "http://nexus-url.com",
+					Repository:  "repository-name",
+					Username:    "user",
+					Password:    "password",
+					MaxVersions: -1,
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("max_versions must not be negative"))
+			})
+
+			It("validates sort keys which aren't named groups of the regexp", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Regexp:     `app-(?P<version>[^-]*)-(?P<build>\d+).zip`,
+					SortKeys:   []string{"version", "commit"},
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("sort_keys 'commit' is not a named group of regexp"))
+			})
+
+			It("validates named groups which are reserved", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Regexp:     `app-(?P<version>[^-]*)-(?P<sha>[0-9a-f]+).zip`,
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("regexp group 'sha' is reserved for a file or metadata of in"))
+			})
+
+			It("validates unsupported version scheme", func() {
+				var source = models.Source{
+					URL:           "http://nexus-url.com",
+					Repository:    "repository-name",
+					Username:      "user",
+					Password:      "password",
+					VersionScheme: "loose",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("version_scheme 'loose' is not supported"))
+			})
+
+			It("validates missing version layout of the timestamp version scheme", func() {
+				var source = models.Source{
+					URL:           "http://nexus-url.com",
+					Repository:    "repository-name",
+					Username:      "user",
+					Password:      "password",
+					VersionScheme: models.VersionSchemeTimestamp,
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("version_layout must be specified with version_scheme 'timestamp'"))
+			})
+
+			It("validates version layout without the timestamp version scheme", func() {
+				var source = models.Source{
+					URL:           "http://nexus-url.com",
+					Repository:    "repository-name",
+					Username:      "user",
+					Password:      "password",
+					VersionScheme: models.VersionSchemeCalver,
+					VersionLayout: "20060102",
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("version_layout can only be used with version_scheme 'timestamp'"))
+			})
+
+			It("validates pre release without the semver version scheme", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					PreRelease: models.PreReleaseExclude,
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("pre_release requires version_scheme 'semver'"))
+			})
+
+			It("validates pre release only without identifiers", func() {
+				var source = models.Source{
+					URL:           "http://nexus-url.com",
+					Repository:    "repository-name",
+					Username:      "user",
+					Password:      "password",
+					VersionScheme: models.VersionSchemeSemver,
+					PreRelease:    models.PreReleaseOnly,
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("pre_release_identifiers must be specified with pre_release 'only'"))
+			})
+
+			It("validates pre release identifiers without pre release only", func() {
+				var source = models.Source{
+					URL:                   "http://nexus-url.com",
+					Repository:            "repository-name",
+					Username:              "user",
+					Password:              "password",
+					VersionScheme:         models.VersionSchemeSemver,
+					PreRelease:            models.PreReleaseExclude,
+					PreReleaseIdentifiers: []string{"rc"},
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("pre_release_identifiers can only be used with pre_release 'only'"))
+			})
+
+			It("validates missing apt distribution", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Format:     models.FormatApt,
+					Apt: models.AptSource{
+						Package: "billing-agent",
+					},
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("apt.distribution must be specified"))
+			})
+
+			It("validates missing nuget package", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Format:     models.FormatNuGet,
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("nuget.package must be specified"))
+			})
+
+			It("validates missing docker regexp", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Format:     models.FormatDocker,
+					Docker: models.DockerSource{
+						Image: "team/api",
+					},
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("regexp must be specified to match the tags"))
+			})
+
+			It("validates invalid helm app version filter", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Format:     models.FormatHelm,
+					Helm: models.HelmSource{
+						Chart:      "ingress-gateway",
+						AppVersion: "2.(",
+					},
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(HavePrefix("helm.app_version is not a valid regexp"))
+			})
+
+			It("validates non SNAPSHOT Maven version", func() {
+				var source = models.Source{
+					URL:        "http://nexus-url.com",
+					Repository: "repository-name",
+					Username:   "user",
+					Password:   "password",
+					Format:     models.FormatMaven2,
+					Maven: models.MavenSource{
+						GroupID:    "com.example",
+						ArtifactID: "app",
+						Version:    "1.2.0",
+					},
+				}
+
+				ok, err := source.IsValid()
+				Ω(ok).Should(BeFalse())
+				Ω(err).Should(Equal("maven.version must be a -SNAPSHOT version"))
+			})
 		})
 	})
 })
@@ -0,0 +1,23 @@
+package models
+
+// NpmPackage struct represent an npm package document
+type NpmPackage struct {
+	Name     string                       `json:"name"`
+	DistTags map[string]string            `json:"dist-tags"`
+	Versions map[string]NpmPackageVersion `json:"versions"`
+}
+
+// NpmPackageVersion struct represent a version of an npm package document
+type NpmPackageVersion struct {
+	Name        string         `json:"name"`
+	Version     string         `json:"version"`
+	Description string         `json:"description"`
+	Dist        NpmPackageDist `json:"dist"`
+}
+
+// NpmPackageDist struct represent the tarball of a version of an npm package
+type NpmPackageDist struct {
+	Tarball   string `json:"tarball"`
+	Shasum    string `json:"shasum"`
+	Integrity string `json:"integrity"`
+}
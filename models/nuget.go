@@ -0,0 +1,17 @@
+package models
+
+import "encoding/xml"
+
+// NuGetVersionIndex struct represent the versions of a package in the flat container
+type NuGetVersionIndex struct {
+	Versions []string `json:"versions"`
+}
+
+// NuGetNuspec struct represent the manifest of a .nupkg
+type NuGetNuspec struct {
+	XMLName  xml.Name `xml:"package"`
+	Metadata struct {
+		ID      string `xml:"id"`
+		Version string `xml:"version"`
+	} `xml:"metadata"`
+}
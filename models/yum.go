@@ -0,0 +1,35 @@
+package models
+
+// YumRepomd struct represent the repomd.xml of a yum repository
+type YumRepomd struct {
+	Data []struct {
+		Type     string      `xml:"type,attr"`
+		Location YumLocation `xml:"location"`
+	} `xml:"data"`
+}
+
+// YumPrimary struct represent the primary metadata of a yum repository
+type YumPrimary struct {
+	Packages []YumPackage `xml:"package"`
+}
+
+// YumPackage struct represent a package of the primary metadata
+type YumPackage struct {
+	Name    string `xml:"name"`
+	Arch    string `xml:"arch"`
+	Version struct {
+		Epoch   string `xml:"epoch,attr"`
+		Version string `xml:"ver,attr"`
+		Release string `xml:"rel,attr"`
+	} `xml:"version"`
+	Checksum struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"checksum"`
+	Location YumLocation `xml:"location"`
+}
+
+// YumLocation struct represent the location of a file relative to the repodata directory
+type YumLocation struct {
+	Href string `xml:"href,attr"`
+}
@@ -11,6 +11,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/trecnoc/nexus-resource/models"
@@ -23,10 +25,31 @@ import (
 type NexusClient interface {
 	ListFiles(repositoryName string, group string) ([]string, error)
 	DownloadFile(repositoryName string, name string, localPath string) error
+	GetFile(repositoryName string, name string) ([]byte, error)
 	UploadFile(repositoryName string, group string, remoteFilename string, localPath string) error
+	UploadComponent(repositoryName string, fields map[string]string, assets map[string]string) error
 	DeleteFile(repositoryName string, name string) error
+	SearchComponents(repositoryName string, parameters map[string]string) ([]models.RepositoryItem, error)
+	GetRepository(repositoryName string) (models.Repository, error)
 	URL(repositoryName string, name string) string
 	SHA(repositoryName string, name string) string
+	ListDockerTags(registryURL string, image string) ([]string, error)
+	GetDockerManifest(registryURL string, image string, reference string) (models.DockerDescriptor, []byte, error)
+	DownloadDockerBlob(registryURL string, image string, digest string, localPath string) error
+	DockerBlobExists(registryURL string, image string, digest string) (bool, error)
+	UploadDockerBlob(registryURL string, image string, digest string, localPath string) error
+	PutDockerManifest(registryURL string, image string, reference string, mediaType string, content []byte) error
+}
+
+// StatusError is the error of a request which received a non-successful
+// status code
+type StatusError struct {
+	Request    string
+	StatusCode int
+}
+
+func (err *StatusError) Error() string {
+	return fmt.Sprintf("%s: non-successful status code received %d", err.Request, err.StatusCode)
 }
 
 type nexusclient struct {
@@ -35,6 +58,11 @@ type nexusclient struct {
 	username   string
 	password   string
 	logger     *utils.StandardLogger
+
+	// Authorization headers for the registry v2 API, by registry and scope,
+	// guarded as the manifests of the tags are fetched concurrently
+	registryTokens      map[string]string
+	registryTokensMutex sync.Mutex
 }
 
 // NewNexusClient creates and returns an NexusClient
@@ -57,6 +85,8 @@ func NewNexusClient(nexusURL string, username string, password string, timeout i
 		username:   username,
 		password:   password,
 		logger:     logger,
+
+		registryTokens: map[string]string{},
 	}
 }
 
@@ -107,25 +137,50 @@ func (client *nexusclient) DownloadFile(repositoryName string, name string, loca
 	return nil
 }
 
+func (client *nexusclient) GetFile(repositoryName string, name string) ([]byte, error) {
+	client.logger.LogSimpleMessage("Reading artifact from repository '%s' with name '%s'", repositoryName, name)
+
+	resp, err := client.doGetRequest(client.URL(repositoryName, name), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
 func (client *nexusclient) UploadFile(repositoryName string, group string, remoteFilename string, localPath string) error {
 	client.logger.LogSimpleMessageAndSay("Uploading artifact '%s' to repository '%s' in group '%s' with name '%s'", localPath, repositoryName, group, remoteFilename)
-	localFile, err := os.Open(localPath)
-	if err != nil {
-		return err
+
+	fields := map[string]string{
+		"raw.directory":       group,
+		"raw.asset1.filename": remoteFilename,
+	}
+	assets := map[string]string{
+		"raw.asset1": localPath,
 	}
-	defer localFile.Close()
+
+	return client.UploadComponent(repositoryName, fields, assets)
+}
+
+func (client *nexusclient) UploadComponent(repositoryName string, fields map[string]string, assets map[string]string) error {
+	client.logger.LogSimpleMessage("In UploadComponent for repository '%s' with %d asset(s)", repositoryName, len(assets))
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile("raw.asset1", filepath.Base(localPath))
-	if err != nil {
-		return err
+
+	for name, localPath := range assets {
+		err := writeFormFile(writer, name, localPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, value := range fields {
+		_ = writer.WriteField(name, value)
 	}
-	_, _ = io.Copy(part, localFile)
-	_ = writer.WriteField("raw.directory", group)
-	_ = writer.WriteField("raw.asset1.filename", remoteFilename)
 
-	err = writer.Close()
+	err := writer.Close()
 	if err != nil {
 		return err
 	}
@@ -154,7 +209,7 @@ func (client *nexusclient) UploadFile(repositoryName string, group string, remot
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("UploadFile: received invalid status code %d", resp.StatusCode)
+		return fmt.Errorf("UploadComponent: received invalid status code %d", resp.StatusCode)
 	}
 
 	return nil
@@ -196,6 +251,10 @@ func (client *nexusclient) URL(repositoryName string, name string) string {
 	client.logger.LogSimpleMessageAndSay("Getting URL for artifact in repository '%s' with name '%s'", repositoryName, name)
 	u, _ := url.Parse(client.nexusURL)
 	u.Path = path.Join(u.Path, "repository", repositoryName, name)
+	// Keep the trailing slash of index pages
+	if strings.HasSuffix(name, "/") {
+		u.Path += "/"
+	}
 	return u.String()
 }
 
@@ -211,6 +270,22 @@ func (client *nexusclient) SHA(repositoryName string, name string) string {
 	return sha
 }
 
+func writeFormFile(writer *multipart.Writer, name string, localPath string) error {
+	localFile, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer localFile.Close()
+
+	part, err := writer.CreateFormFile(name, filepath.Base(localPath))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, localFile)
+	return err
+}
+
 func (client *nexusclient) doGetRequest(requestURL string, parameters map[string]string) (*http.Response, error) {
 	u, _ := url.Parse(requestURL)
 	if parameters != nil || len(parameters) > 0 {
@@ -235,11 +310,30 @@ func (client *nexusclient) doGetRequest(requestURL string, parameters map[string
 		return nil, err
 	}
 	if !(resp.StatusCode >= 200 && resp.StatusCode <= 299) {
-		return nil, fmt.Errorf("doGetResquest: non-successful status code received %d", resp.StatusCode)
+		resp.Body.Close()
+		return nil, &StatusError{Request: "doGetResquest", StatusCode: resp.StatusCode}
 	}
 	return resp, nil
 }
 
+func (client *nexusclient) GetRepository(repositoryName string) (models.Repository, error) {
+	client.logger.LogSimpleMessage("In GetRepository for repository '%s'", repositoryName)
+
+	response, err := client.doGetRequestPath("service/rest/v1/repositories/"+url.PathEscape(repositoryName), nil)
+	if err != nil {
+		return models.Repository{}, fmt.Errorf("repository '%s' not found: %w", repositoryName, err)
+	}
+	defer response.Body.Close()
+
+	var repository models.Repository
+	err = json.NewDecoder(response.Body).Decode(&repository)
+	if err != nil {
+		return models.Repository{}, err
+	}
+
+	return repository, nil
+}
+
 func (client *nexusclient) doGetRequestPath(requestPath string, parameters map[string]string) (*http.Response, error) {
 	u, _ := url.Parse(client.nexusURL)
 	u.Path = path.Join(u.Path, requestPath)
@@ -250,20 +344,37 @@ func (client *nexusclient) doGetRequestPath(requestPath string, parameters map[s
 func (client *nexusclient) getRepositoryGroupContent(repositoryName string, group string) (map[string]models.RepositoryItem, error) {
 	client.logger.LogSimpleMessage("In getRepositoryGroupContent for repository '%s' and group '%s'", repositoryName, group)
 	repositoryItems := map[string]models.RepositoryItem{}
+
+	items, err := client.SearchComponents(repositoryName, map[string]string{"group": group})
+	if err != nil {
+		return repositoryItems, err
+	}
+
+	for _, item := range items {
+		repositoryItems[item.Name] = item
+	}
+
+	client.logger.LogSimpleMessage("In getRepositoryGroupContent found a total of %d item(s)", len(repositoryItems))
+
+	return repositoryItems, nil
+}
+
+func (client *nexusclient) SearchComponents(repositoryName string, parameters map[string]string) ([]models.RepositoryItem, error) {
+	client.logger.LogSimpleMessage("In SearchComponents for repository '%s' with parameters %v", repositoryName, parameters)
 	continuation := ""
 
 	getContents := func() (repositoryItems models.RespositoryItems, err error) {
-		var parameters map[string]string
-
-		parameters = make(map[string]string)
-		parameters["repository"] = repositoryName
-		parameters["group"] = group
+		searchParameters := make(map[string]string)
+		for key, value := range parameters {
+			searchParameters[key] = value
+		}
+		searchParameters["repository"] = repositoryName
 
 		if continuation != "" {
-			parameters["continuationToken"] = continuation
+			searchParameters["continuationToken"] = continuation
 		}
 
-		response, err := client.doGetRequestPath("service/rest/v1/search", parameters)
+		response, err := client.doGetRequestPath("service/rest/v1/search", searchParameters)
 		if err != nil {
 			return repositoryItems, err
 		}
@@ -279,7 +390,7 @@ func (client *nexusclient) getRepositoryGroupContent(repositoryName string, grou
 	for {
 		resp, err := getContents()
 		if err != nil {
-			return repositoryItems, err
+			return items, err
 		}
 
 		items = append(items, resp.Items...)
@@ -288,17 +399,11 @@ func (client *nexusclient) getRepositoryGroupContent(repositoryName string, grou
 			break
 		}
 
-		client.logger.LogSimpleMessage("In getRepositoryGroupContent got a non-nil ContinuationToken, fetching next results")
+		client.logger.LogSimpleMessage("In SearchComponents got a non-nil ContinuationToken, fetching next results")
 		continuation = resp.ContinuationToken
 	}
 
-	for _, item := range items {
-		repositoryItems[item.Name] = item
-	}
-
-	client.logger.LogSimpleMessage("In getRepositoryGroupContent found a total of %d item(s)", len(repositoryItems))
-
-	return repositoryItems, nil
+	return items, nil
 }
 
 func (client *nexusclient) getRepositoryItem(repositoryName string, name string) (models.RepositoryItem, error) {
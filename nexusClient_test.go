@@ -0,0 +1,41 @@
+package nexusresource_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource"
+)
+
+var _ = Describe("GetRepository", func() {
+	var (
+		server     *httptest.Server
+		statusCode int
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(statusCode)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("returns the status code of a failed lookup", func() {
+		statusCode = http.StatusForbidden
+		client := nexusresource.NewNexusClient(server.URL, "user", "password", 0, false)
+
+		_, err := client.GetRepository("repository-name")
+		Ω(err).Should(MatchError("repository 'repository-name' not found: doGetResquest: non-successful status code received 403"))
+
+		var statusErr *nexusresource.StatusError
+		Ω(errors.As(err, &statusErr)).Should(BeTrue())
+		Ω(statusErr.StatusCode).Should(Equal(http.StatusForbidden))
+	})
+})
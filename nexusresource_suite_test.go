@@ -0,0 +1,13 @@
+package nexusresource_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestNexusResource(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Nexus Resource Suite")
+}
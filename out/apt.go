@@ -0,0 +1,48 @@
+package out
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// runApt uploads a .deb through the components API, Nexus then regenerates and
+// signs the metadata of the distribution
+func (command *Command) runApt(sourceDir string, request Request) (Response, error) {
+	apt := request.Source.Apt
+
+	localPath, err := command.match(request.Params.File, sourceDir)
+	if err != nil {
+		return Response{}, err
+	}
+
+	localFileName := filepath.Base(localPath)
+	remotePath := "pool/" + apt.Package[:1] + "/" + apt.Package + "/" + localFileName
+
+	extraction, ok := versions.ExtractApt(remotePath, apt)
+	if !ok {
+		return Response{}, fmt.Errorf("%s is not named as a .deb of apt package %s", localFileName, apt.Package)
+	}
+
+	err = command.nexusclient.UploadComponent(
+		request.Source.Repository,
+		map[string]string{},
+		map[string]string{"apt.asset": localPath},
+	)
+	if err != nil {
+		return Response{}, err
+	}
+
+	metadata := command.metadata(request.Source.Repository, localFileName, remotePath)
+	metadata = append(metadata, models.MetadataPair{
+		Name:  "version",
+		Value: extraction.VersionNumber,
+	})
+
+	return Response{
+		Version:  models.Version{Path: remotePath},
+		Metadata: metadata,
+	}, nil
+}
@@ -4,11 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"path/filepath"
 	"strings"
 
 	"github.com/trecnoc/nexus-resource"
 	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
 )
 
 // Command struct for Out
@@ -31,12 +33,51 @@ func (command *Command) Run(sourceDir string, request Request) (Response, error)
 		return Response{}, errors.New(message)
 	}
 
-	localPath, err := command.match(request.Params, sourceDir)
+	source, err := command.resolveRepository(request.Source)
 	if err != nil {
 		return Response{}, err
 	}
-	repositoryName := request.Source.Repository
+	request.Source = source
+
+	switch request.Source.Format {
+	case models.FormatMaven2:
+		return command.runMaven(sourceDir, request)
+	case models.FormatNpm:
+		return command.runNpm(sourceDir, request)
+	case models.FormatPypi:
+		return command.runPypi(sourceDir, request)
+	case models.FormatHelm:
+		return command.runHelm(sourceDir, request)
+	case models.FormatDocker:
+		return command.runDocker(sourceDir, request)
+	case models.FormatNuGet:
+		return command.runNuGet(sourceDir, request)
+	case models.FormatGo:
+		return Response{}, errors.New("go module proxies do not support uploads")
+	case models.FormatApt:
+		return command.runApt(sourceDir, request)
+	case models.FormatYum:
+		return command.runYum(sourceDir, request)
+	}
+
 	group := request.Source.Group
+	if request.Params.Group != "" {
+		group = request.Params.Group
+	}
+
+	if group == "" && len(request.Source.Selectors) > 0 {
+		return Response{}, errors.New("group must be specified in params to upload to a source with selectors")
+	}
+
+	if group != "" && !strings.HasPrefix(group, "/") {
+		return Response{}, fmt.Errorf("group '%s' must start with '/'", group)
+	}
+
+	localPath, err := command.match(request.Params.File, sourceDir)
+	if err != nil {
+		return Response{}, err
+	}
+	repositoryName := request.Source.Repository
 	localFileName := filepath.Base(localPath)
 
 	err = command.nexusclient.UploadFile(
@@ -50,27 +91,80 @@ func (command *Command) Run(sourceDir string, request Request) (Response, error)
 	}
 
 	var remotePath string
-	if request.Source.Group == "/" {
+	if group == "/" {
 		remotePath = localFileName
 	} else {
-		remotePath = strings.TrimPrefix(request.Source.Group, "/") + "/" + localFileName
+		remotePath = strings.TrimPrefix(group, "/") + "/" + localFileName
 	}
 
 	version := models.Version{}
 	version.Path = remotePath
 
+	if request.Source.VersionIdentity == models.VersionIdentityVersion {
+		extraction, ok := versions.ExtractVersion(remotePath, request.Source)
+		if !ok {
+			return Response{}, fmt.Errorf("regexp does not match the uploaded file: %s", remotePath)
+		}
+		version = models.Version{VersionNumber: extraction.VersionNumber}
+	}
+
+	if request.Source.TrackRedeploys != "" {
+		asset, err := versions.FindAsset(command.nexusclient, repositoryName, remotePath)
+		if err != nil {
+			return Response{}, err
+		}
+		version = versions.WithContent(request.Source, version, asset.Checksum.Sha256, asset.UploadTime())
+	}
+
 	return Response{
 		Version:  version,
 		Metadata: command.metadata(repositoryName, localFileName, remotePath),
 	}, nil
 }
 
-func (command *Command) match(params Params, sourceDir string) (string, error) {
+// resolveRepository rejects a group repository, which can't be uploaded to,
+// and detects the format of the repository when it isn't configured. A user
+// who isn't allowed to read the repository can't look it up, its format then
+// defaults to raw
+func (command *Command) resolveRepository(source models.Source) (models.Source, error) {
+	repository, err := command.nexusclient.GetRepository(source.Repository)
+
+	var statusErr *nexusresource.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusForbidden {
+		if source.Format == "" {
+			fmt.Fprintf(command.stderr, "not allowed to read repository '%s', defaulting to raw\n", source.Repository)
+			source.Format = models.FormatRaw
+		}
+		return source, nil
+	}
+	if err != nil {
+		return source, err
+	}
+
+	if repository.Type == models.RepositoryTypeGroup {
+		return source, fmt.Errorf("repository '%s' is a group repository and can't be uploaded to, use one of its hosted members", source.Repository)
+	}
+
+	if source.Format != "" {
+		return source, nil
+	}
+
+	source.Format, err = versions.RepositoryFormat(repository)
+	if err != nil {
+		return source, err
+	}
+
+	if ok, message := source.IsValid(); !ok {
+		return source, errors.New(message)
+	}
+
+	return source, nil
+}
+
+func (command *Command) match(pattern string, sourceDir string) (string, error) {
 	var matches []string
 	var err error
-	var pattern string
 
-	pattern = params.File
 	matches, err = filepath.Glob(filepath.Join(sourceDir, pattern))
 
 	if err != nil {
@@ -1,15 +1,23 @@
 package out_test
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/trecnoc/nexus-resource/out"
 
 	"github.com/onsi/gomega/gbytes"
+	"github.com/trecnoc/nexus-resource"
 	"github.com/trecnoc/nexus-resource/fakes"
 	"github.com/trecnoc/nexus-resource/models"
 )
@@ -48,6 +56,7 @@ var _ = Describe("Out Command", func() {
 			nexusclient.URLStub = func(repositoryName string, remotePath string) string {
 				return "http://nexus-url.com/" + filepath.Join(repositoryName, remotePath)
 			}
+			nexusclient.GetRepositoryReturns(models.Repository{Name: "repository-name", Format: "raw", Type: "hosted"}, nil)
 			stderr = gbytes.NewBuffer()
 			command = NewCommand(stderr, nexusclient)
 		})
@@ -68,6 +77,104 @@ var _ = Describe("Out Command", func() {
 			file.Close()
 		}
 
+		createTarball := func(path string, name string, contents string) {
+			createFile(path)
+			file, err := os.Create(filepath.Join(sourceDir, path))
+			Ω(err).ShouldNot(HaveOccurred())
+			defer file.Close()
+
+			gzipWriter := gzip.NewWriter(file)
+			tarWriter := tar.NewWriter(gzipWriter)
+			err = tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents))})
+			Ω(err).ShouldNot(HaveOccurred())
+			_, err = tarWriter.Write([]byte(contents))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(tarWriter.Close()).Should(Succeed())
+			Ω(gzipWriter.Close()).Should(Succeed())
+		}
+
+		Describe("resolving the repository", func() {
+			It("detects the format of the repository", func() {
+				nexusclient.GetRepositoryReturns(models.Repository{Name: "repository-name", Format: "pypi", Type: "hosted"}, nil)
+				request.Source.Pypi = models.PypiSource{Package: "billing"}
+				request.Params.File = "dist/*.whl"
+				createFile("dist/billing-1.2.0-py3-none-any.whl")
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, _, assets := nexusclient.UploadComponentArgsForCall(0)
+				Ω(assets).Should(HaveKey("pypi.asset"))
+			})
+
+			It("errors on formats which are not supported", func() {
+				nexusclient.GetRepositoryReturns(models.Repository{Name: "repository-name", Format: "conan", Type: "hosted"}, nil)
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("repository 'repository-name' has format 'conan' which is not supported"))
+			})
+
+			It("errors on group repositories", func() {
+				nexusclient.GetRepositoryReturns(models.Repository{Name: "repository-name", Format: "raw", Type: "group"}, nil)
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("repository 'repository-name' is a group repository and can't be uploaded to, use one of its hosted members"))
+				Ω(nexusclient.UploadFileCallCount()).Should(Equal(0))
+			})
+
+			It("errors on group repositories when the format is configured", func() {
+				nexusclient.GetRepositoryReturns(models.Repository{Name: "repository-name", Format: "raw", Type: "group"}, nil)
+				request.Source.Format = models.FormatRaw
+				request.Source.Group = "/files"
+				request.Params.File = "a/*.tgz"
+				createFile("a/file.tgz")
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("repository 'repository-name' is a group repository and can't be uploaded to, use one of its hosted members"))
+				Ω(nexusclient.UploadFileCallCount()).Should(Equal(0))
+			})
+
+			It("errors when the repository isn't found", func() {
+				nexusclient.GetRepositoryReturns(models.Repository{}, fmt.Errorf("repository 'repository-name' not found: %w", &nexusresource.StatusError{Request: "doGetResquest", StatusCode: 404}))
+				request.Source.Group = "/files"
+				request.Params.File = "a/*.tgz"
+				createFile("a/file.tgz")
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("repository 'repository-name' not found: doGetResquest: non-successful status code received 404"))
+				Ω(nexusclient.UploadFileCallCount()).Should(Equal(0))
+			})
+
+			Context("when the user isn't allowed to read the repository", func() {
+				BeforeEach(func() {
+					nexusclient.GetRepositoryReturns(models.Repository{}, fmt.Errorf("repository 'repository-name' not found: %w", &nexusresource.StatusError{Request: "doGetResquest", StatusCode: 403}))
+					request.Source.Group = "/files"
+					request.Params.File = "a/*.tgz"
+					createFile("a/file.tgz")
+				})
+
+				It("defaults to raw", func() {
+					response, err := command.Run(sourceDir, request)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(response.Version.Path).Should(Equal("files/file.tgz"))
+					Ω(stderr).Should(gbytes.Say("not allowed to read repository 'repository-name', defaulting to raw"))
+				})
+
+				It("uploads with the configured format", func() {
+					request.Source.Format = models.FormatPypi
+					request.Source.Group = ""
+					request.Source.Pypi = models.PypiSource{Package: "billing"}
+					request.Params.File = "dist/*.whl"
+					createFile("dist/billing-1.2.0-py3-none-any.whl")
+
+					_, err := command.Run(sourceDir, request)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(nexusclient.UploadComponentCallCount()).Should(Equal(1))
+					Ω(stderr).ShouldNot(gbytes.Say("defaulting to raw"))
+				})
+			})
+		})
+
 		Describe("finding files to upload with File param", func() {
 			It("does not error if there is a single match", func() {
 				request.Source.Group = "/files"
@@ -159,5 +266,583 @@ var _ = Describe("Out Command", func() {
 			})
 		})
 
+		Describe("uploading with the version number as identity", func() {
+			BeforeEach(func() {
+				request.Source.Group = "/files"
+				request.Source.Regexp = "files/file-(.*).tgz"
+				request.Source.VersionIdentity = models.VersionIdentityVersion
+				request.Params.File = "a/*.tgz"
+			})
+
+			It("returns the version number of the uploaded file", func() {
+				createFile("a/file-1.4.0.tgz")
+
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response.Version).Should(Equal(models.Version{VersionNumber: "1.4.0"}))
+			})
+
+			It("errors when the regexp doesn't match the uploaded file", func() {
+				createFile("a/other.tgz")
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("regexp does not match the uploaded file: files/other.tgz"))
+			})
+		})
+
+		Describe("uploading to a source with selectors", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatRaw
+				request.Source.Selectors = []models.Selector{
+					{Group: "/legacy/app", Regexp: "legacy/app/app_(.*).tar.gz"},
+					{Group: "/app/releases", Regexp: "app/releases/app-(.*).tgz"},
+				}
+				request.Params.File = "a/*.tgz"
+				createFile("a/app-2.1.0.tgz")
+			})
+
+			It("uploads to the group of the params", func() {
+				request.Params.Group = "/app/releases"
+
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, group, _, _ := nexusclient.UploadFileArgsForCall(0)
+				Ω(group).Should(Equal("/app/releases"))
+				Ω(response.Version.Path).Should(Equal("app/releases/app-2.1.0.tgz"))
+			})
+
+			It("errors without a group in the params", func() {
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("group must be specified in params to upload to a source with selectors"))
+				Ω(nexusclient.UploadFileCallCount()).Should(Equal(0))
+			})
+		})
+
+		Describe("uploading with tracked redeploys", func() {
+			BeforeEach(func() {
+				request.Source.Group = "/files"
+				request.Params.File = "a/*.tgz"
+				createFile("a/file.tgz")
+
+				nexusclient.SearchComponentsReturns([]models.RepositoryItem{{
+					Name: "files/file.tgz",
+					Assets: []models.RepositoryItemAsset{{
+						Path:         "files/file.tgz",
+						Checksum:     models.RepositoryItemAssetsChecksum{Sha256: "abc123"},
+						LastModified: time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC),
+					}},
+				}}, nil)
+			})
+
+			It("includes the sha256 of the uploaded asset in the version", func() {
+				request.Source.TrackRedeploys = models.TrackRedeploysSha256
+
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				repositoryName, parameters := nexusclient.SearchComponentsArgsForCall(0)
+				Ω(repositoryName).Should(Equal("repository-name"))
+				Ω(parameters).Should(Equal(map[string]string{"name": "files/file.tgz"}))
+				Ω(response.Version).Should(Equal(models.Version{Path: "files/file.tgz", Sha256: "abc123"}))
+			})
+
+			It("includes the last modification of the uploaded asset in the version", func() {
+				request.Source.TrackRedeploys = models.TrackRedeploysLastModified
+
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(response.Version).Should(Equal(models.Version{Path: "files/file.tgz", LastModified: "2026-10-17T03:00:00Z"}))
+			})
+		})
+
+		Describe("uploading to a maven2 repository", func() {
+			writeFile := func(path string, contents string) {
+				createFile(path)
+				err := ioutil.WriteFile(filepath.Join(sourceDir, path), []byte(contents), 0644)
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+
+			BeforeEach(func() {
+				request.Source.Format = models.FormatMaven2
+				request.Source.Maven = models.MavenSource{
+					GroupID:    "com.example",
+					ArtifactID: "app",
+				}
+				request.Params.File = "build/app-*.jar"
+				request.Params.VersionFile = "version/number"
+				createFile("build/app-1.4.0.jar")
+				writeFile("version/number", "1.4.0\n")
+			})
+
+			It("uploads the component with a generated POM", func() {
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.UploadFileCallCount()).Should(Equal(0))
+				Ω(nexusclient.UploadComponentCallCount()).Should(Equal(1))
+				repositoryName, fields, assets := nexusclient.UploadComponentArgsForCall(0)
+				Ω(repositoryName).Should(Equal("repository-name"))
+				Ω(fields).Should(Equal(map[string]string{
+					"maven2.groupId":          "com.example",
+					"maven2.artifactId":       "app",
+					"maven2.version":          "1.4.0",
+					"maven2.generate-pom":     "true",
+					"maven2.asset1.extension": "jar",
+				}))
+				Ω(assets).Should(Equal(map[string]string{
+					"maven2.asset1": filepath.Join(sourceDir, "build/app-1.4.0.jar"),
+				}))
+
+				Ω(response.Version).Should(Equal(models.Version{
+					Path: "com/example/app/1.4.0/app-1.4.0.jar",
+					GAV:  "com.example:app:1.4.0",
+				}))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{
+					Name:  "url",
+					Value: "http://nexus-url.com/repository-name/com/example/app/1.4.0/app-1.4.0.jar",
+				}))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{
+					Name:  "gav",
+					Value: "com.example:app:1.4.0",
+				}))
+			})
+
+			It("uploads additional assets with their extension and classifier", func() {
+				createFile("build/app-1.4.0-sources.jar")
+				createFile("build/app-1.4.0.zip")
+				request.Params.File = "build/app-1.4.0.jar"
+				request.Params.Assets = []MavenAsset{
+					{File: "build/*-sources.jar", Classifier: "sources"},
+					{File: "build/*.zip", Classifier: "dist"},
+				}
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, fields, assets := nexusclient.UploadComponentArgsForCall(0)
+				Ω(assets).Should(HaveLen(3))
+				Ω(assets).Should(HaveKeyWithValue("maven2.asset2", filepath.Join(sourceDir, "build/app-1.4.0-sources.jar")))
+				Ω(fields).Should(HaveKeyWithValue("maven2.asset2.extension", "jar"))
+				Ω(fields).Should(HaveKeyWithValue("maven2.asset2.classifier", "sources"))
+				Ω(assets).Should(HaveKeyWithValue("maven2.asset3", filepath.Join(sourceDir, "build/app-1.4.0.zip")))
+				Ω(fields).Should(HaveKeyWithValue("maven2.asset3.extension", "zip"))
+				Ω(fields).Should(HaveKeyWithValue("maven2.asset3.classifier", "dist"))
+			})
+
+			It("uploads a supplied POM instead of generating one", func() {
+				request.Params.VersionFile = ""
+				request.Params.PomFile = "build/pom.xml"
+				writeFile("build/pom.xml", `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <version>1.5.0</version>
+  </parent>
+  <artifactId>app</artifactId>
+</project>`)
+
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, fields, assets := nexusclient.UploadComponentArgsForCall(0)
+				Ω(fields).Should(Equal(map[string]string{
+					"maven2.generate-pom":     "false",
+					"maven2.asset1.extension": "pom",
+					"maven2.asset2.extension": "jar",
+				}))
+				Ω(assets).Should(Equal(map[string]string{
+					"maven2.asset1": filepath.Join(sourceDir, "build/pom.xml"),
+					"maven2.asset2": filepath.Join(sourceDir, "build/app-1.4.0.jar"),
+				}))
+				Ω(response.Version.GAV).Should(Equal("com.example:app:1.5.0"))
+			})
+
+			It("errors when the POM coordinates don't match the source", func() {
+				request.Params.PomFile = "build/pom.xml"
+				writeFile("build/pom.xml", `<project><groupId>org.other</groupId><artifactId>app</artifactId><version>1.0.0</version></project>`)
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("pom coordinates org.other:app do not match the source com.example:app"))
+				Ω(nexusclient.UploadComponentCallCount()).Should(Equal(0))
+			})
+
+			It("errors when no version is provided", func() {
+				request.Params.VersionFile = ""
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(HaveOccurred())
+				Ω(nexusclient.UploadComponentCallCount()).Should(Equal(0))
+			})
+
+			Context("when the version is a snapshot", func() {
+				var checksums map[string]string
+
+				BeforeEach(func() {
+					request.Params.VersionFile = ""
+					request.Source.Maven.Version = "1.4.0-SNAPSHOT"
+					writeFile("build/app-1.4.0.jar", "snapshot build")
+
+					checksums = map[string]string{
+						"com/example/app/1.4.0-SNAPSHOT/app-1.4.0-20261017.101500-3.jar.sha1": "ca29ccdc3ada463dce6bd411a6efbffefce36a2c",
+						"com/example/app/1.4.0-SNAPSHOT/app-1.4.0-20261017.102000-4.jar.sha1": "fdc99153e1965a7d16f0a11f4af1088559bc6706  app-1.4.0-20261017.102000-4.jar",
+					}
+					nexusclient.GetFileStub = func(repositoryName string, name string) ([]byte, error) {
+						if name == "com/example/app/1.4.0-SNAPSHOT/maven-metadata.xml" {
+							return []byte(`<metadata><versioning><snapshotVersions>
+  <snapshotVersion><extension>jar</extension><value>1.4.0-20261017.101500-3</value></snapshotVersion>
+  <snapshotVersion><extension>jar</extension><value>1.4.0-20261017.102000-4</value></snapshotVersion>
+</snapshotVersions></versioning></metadata>`), nil
+						}
+
+						checksum, ok := checksums[name]
+						if !ok {
+							return nil, errors.New("not found")
+						}
+						return []byte(checksum), nil
+					}
+				})
+
+				It("resolves the uploaded snapshot to its timestamped build by its checksum", func() {
+					response, err := command.Run(sourceDir, request)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(response.Version).Should(Equal(models.Version{
+						Path: "com/example/app/1.4.0-SNAPSHOT/app-1.4.0-20261017.101500-3.jar",
+						GAV:  "com.example:app:1.4.0-20261017.101500-3",
+					}))
+				})
+
+				It("resolves the newest build with the checksum of the upload", func() {
+					checksums["com/example/app/1.4.0-SNAPSHOT/app-1.4.0-20261017.102000-4.jar.sha1"] = "CA29CCDC3ADA463DCE6BD411A6EFBFFEFCE36A2C"
+
+					response, err := command.Run(sourceDir, request)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(response.Version.Path).Should(Equal("com/example/app/1.4.0-SNAPSHOT/app-1.4.0-20261017.102000-4.jar"))
+				})
+
+				It("errors when no build has the checksum of the upload", func() {
+					writeFile("build/app-1.4.0.jar", "another build")
+
+					_, err := command.Run(sourceDir, request)
+					Ω(err).Should(MatchError("the uploaded build of 1.4.0-SNAPSHOT was not found in the maven metadata"))
+				})
+
+				It("errors when the maven metadata can't be read", func() {
+					nexusclient.GetFileStub = nil
+					nexusclient.GetFileReturns(nil, errors.New("not found"))
+
+					_, err := command.Run(sourceDir, request)
+					Ω(err).Should(MatchError("reading maven metadata: not found"))
+				})
+			})
+		})
+
+		Describe("publishing to an npm repository", func() {
+			createPackage := func(path string, manifest string) {
+				createTarball(path, "package/package.json", manifest)
+			}
+
+			BeforeEach(func() {
+				request.Source.Format = models.FormatNpm
+				request.Source.Npm = models.NpmSource{Package: "@frontend/widgets"}
+				request.Params.File = "pack/*.tgz"
+			})
+
+			It("uploads the packed tarball", func() {
+				createPackage("pack/frontend-widgets-2.2.0.tgz", `{"name": "@frontend/widgets", "version": "2.2.0"}`)
+
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.UploadComponentCallCount()).Should(Equal(1))
+				repositoryName, _, assets := nexusclient.UploadComponentArgsForCall(0)
+				Ω(repositoryName).Should(Equal("repository-name"))
+				Ω(assets).Should(Equal(map[string]string{
+					"npm.asset": filepath.Join(sourceDir, "pack/frontend-widgets-2.2.0.tgz"),
+				}))
+
+				Ω(response.Version.Path).Should(Equal("@frontend/widgets/-/widgets-2.2.0.tgz"))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "version", Value: "2.2.0"}))
+			})
+
+			It("errors when the tarball is another package", func() {
+				createPackage("pack/other-1.0.0.tgz", `{"name": "other", "version": "1.0.0"}`)
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("npm package other does not match the source @frontend/widgets"))
+				Ω(nexusclient.UploadComponentCallCount()).Should(Equal(0))
+			})
+		})
+
+		Describe("uploading to a pypi repository", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatPypi
+				request.Source.Pypi = models.PypiSource{Package: "Friendly-Bard"}
+				request.Params.File = "dist/*.whl"
+			})
+
+			It("uploads the wheel", func() {
+				createFile("dist/friendly_bard-1.2.0-py3-none-any.whl")
+
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.UploadComponentCallCount()).Should(Equal(1))
+				_, _, assets := nexusclient.UploadComponentArgsForCall(0)
+				Ω(assets).Should(Equal(map[string]string{
+					"pypi.asset": filepath.Join(sourceDir, "dist/friendly_bard-1.2.0-py3-none-any.whl"),
+				}))
+
+				Ω(response.Version.Path).Should(Equal("packages/friendly-bard/1.2.0/friendly_bard-1.2.0-py3-none-any.whl"))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "version", Value: "1.2.0"}))
+			})
+
+			It("errors when the file is not a distribution of the package", func() {
+				createFile("dist/other-1.2.0-py3-none-any.whl")
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("pypi package other does not match the source Friendly-Bard"))
+				Ω(nexusclient.UploadComponentCallCount()).Should(Equal(0))
+			})
+		})
+
+		Describe("uploading to a helm repository", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatHelm
+				request.Source.Helm = models.HelmSource{Chart: "ingress-gateway"}
+				request.Params.File = "charts/*.tgz"
+			})
+
+			It("uploads the packaged chart", func() {
+				createTarball("charts/ingress-gateway-0.4.1.tgz", "ingress-gateway/Chart.yaml", "apiVersion: v2\nname: ingress-gateway\nversion: 0.4.1\nappVersion: \"2.8\"\n")
+
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, _, assets := nexusclient.UploadComponentArgsForCall(0)
+				Ω(assets).Should(Equal(map[string]string{
+					"helm.asset": filepath.Join(sourceDir, "charts/ingress-gateway-0.4.1.tgz"),
+				}))
+
+				Ω(response.Version.Path).Should(Equal("ingress-gateway-0.4.1.tgz"))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "version", Value: "0.4.1"}))
+			})
+
+			It("errors when the package is another chart", func() {
+				createTarball("charts/other-1.0.0.tgz", "other/Chart.yaml", "name: other\nversion: 1.0.0\n")
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("helm chart other does not match the source ingress-gateway"))
+				Ω(nexusclient.UploadComponentCallCount()).Should(Equal(0))
+			})
+		})
+
+		Describe("uploading to an apt repository", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatApt
+				request.Source.Apt = models.AptSource{Package: "billing-agent", Distribution: "jammy"}
+				request.Params.File = "debs/*.deb"
+			})
+
+			It("uploads the package as an apt asset", func() {
+				createFile("debs/billing-agent_1.2.0-1_amd64.deb")
+
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, fields, assets := nexusclient.UploadComponentArgsForCall(0)
+				Ω(fields).Should(BeEmpty())
+				Ω(assets).Should(Equal(map[string]string{
+					"apt.asset": filepath.Join(sourceDir, "debs/billing-agent_1.2.0-1_amd64.deb"),
+				}))
+
+				Ω(response.Version.Path).Should(Equal("pool/b/billing-agent/billing-agent_1.2.0-1_amd64.deb"))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "version", Value: "1.2.0-1"}))
+			})
+
+			It("errors when the package is another package", func() {
+				createFile("debs/billing-cli_3.0.0-1_all.deb")
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("billing-cli_3.0.0-1_all.deb is not named as a .deb of apt package billing-agent"))
+				Ω(nexusclient.UploadComponentCallCount()).Should(Equal(0))
+			})
+		})
+
+		Describe("uploading to a yum repository", func() {
+			BeforeEach(func() {
+				request.Source.Format = models.FormatYum
+				request.Source.Yum = models.YumSource{Package: "billing-agent", Directory: "/el9"}
+				request.Params.File = "rpms/*.rpm"
+				createFile("rpms/billing-agent-1.2.0-1.el9.x86_64.rpm")
+			})
+
+			It("uploads the package in the directory of the source", func() {
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, fields, assets := nexusclient.UploadComponentArgsForCall(0)
+				Ω(fields).Should(Equal(map[string]string{
+					"yum.directory":      "el9",
+					"yum.asset.filename": "billing-agent-1.2.0-1.el9.x86_64.rpm",
+				}))
+				Ω(assets).Should(Equal(map[string]string{
+					"yum.asset": filepath.Join(sourceDir, "rpms/billing-agent-1.2.0-1.el9.x86_64.rpm"),
+				}))
+
+				Ω(response.Version.Path).Should(Equal("el9/billing-agent-1.2.0-1.el9.x86_64.rpm"))
+			})
+
+			It("uploads the package in the directory of the params", func() {
+				request.Params.Directory = "el9/x86_64"
+
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, fields, _ := nexusclient.UploadComponentArgsForCall(0)
+				Ω(fields).Should(HaveKeyWithValue("yum.directory", "el9/x86_64"))
+				Ω(response.Version.Path).Should(Equal("el9/x86_64/billing-agent-1.2.0-1.el9.x86_64.rpm"))
+			})
+		})
+
+		Describe("pushing to a nuget repository", func() {
+			createNupkg := func(path string, nuspec string) {
+				createFile(path)
+				file, err := os.Create(filepath.Join(sourceDir, path))
+				Ω(err).ShouldNot(HaveOccurred())
+				defer file.Close()
+
+				zipWriter := zip.NewWriter(file)
+				writer, err := zipWriter.Create("Contoso.Billing.nuspec")
+				Ω(err).ShouldNot(HaveOccurred())
+				_, err = writer.Write([]byte(nuspec))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(zipWriter.Close()).Should(Succeed())
+			}
+
+			BeforeEach(func() {
+				request.Source.Format = models.FormatNuGet
+				request.Source.NuGet = models.NuGetSource{Package: "Contoso.Billing"}
+				request.Params.File = "packages/*.nupkg"
+			})
+
+			It("pushes the package", func() {
+				createNupkg("packages/Contoso.Billing.2.1.0.nupkg", `<?xml version="1.0" encoding="utf-8"?>
+<package xmlns="http://schemas.microsoft.com/packaging/2013/05/nuspec.xsd">
+  <metadata>
+    <id>Contoso.Billing</id>
+    <version>2.1.0</version>
+  </metadata>
+</package>`)
+
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, _, assets := nexusclient.UploadComponentArgsForCall(0)
+				Ω(assets).Should(Equal(map[string]string{
+					"nuget.asset": filepath.Join(sourceDir, "packages/Contoso.Billing.2.1.0.nupkg"),
+				}))
+
+				Ω(response.Version.Path).Should(Equal("v3-flatcontainer/contoso.billing/2.1.0/contoso.billing.2.1.0.nupkg"))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "version", Value: "2.1.0"}))
+			})
+
+			It("errors when the package is another package", func() {
+				createNupkg("packages/Contoso.Api.1.0.0.nupkg", `<package><metadata><id>Contoso.Api</id><version>1.0.0</version></metadata></package>`)
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("nuget package Contoso.Api does not match the source Contoso.Billing"))
+				Ω(nexusclient.UploadComponentCallCount()).Should(Equal(0))
+			})
+		})
+
+		Describe("pushing to a docker repository", func() {
+			var manifestDigest string
+
+			BeforeEach(func() {
+				request.Source.Format = models.FormatDocker
+				request.Source.Repository = "docker-hosted"
+				request.Source.Regexp = `v(.*)`
+				request.Source.Docker = models.DockerSource{Image: "team/api"}
+				request.Params.File = "image/image.tar"
+				request.Params.Tags = []string{"v1.4.2", "latest"}
+
+				config := `{"architecture":"amd64","os":"linux"}`
+				layer := "layer-contents"
+				manifest := `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json",` +
+					`"config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"` + models.DockerDigest([]byte(config)) + `","size":37},` +
+					`"layers":[{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":"` + models.DockerDigest([]byte(layer)) + `","size":14}]}`
+				manifestDigest = models.DockerDigest([]byte(manifest))
+				index := `{"schemaVersion":2,"manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"` + manifestDigest + `","size":1}]}`
+
+				createFile("image/image.tar")
+				file, err := os.Create(filepath.Join(sourceDir, "image/image.tar"))
+				Ω(err).ShouldNot(HaveOccurred())
+				defer file.Close()
+
+				tarWriter := tar.NewWriter(file)
+				for name, contents := range map[string]string{
+					"oci-layout": `{"imageLayoutVersion":"1.0.0"}`,
+					"index.json": index,
+					"blobs/sha256/" + strings.TrimPrefix(models.DockerDigest([]byte(config)), "sha256:"): config,
+					"blobs/sha256/" + strings.TrimPrefix(models.DockerDigest([]byte(layer)), "sha256:"):  layer,
+					"blobs/sha256/" + strings.TrimPrefix(manifestDigest, "sha256:"):                      manifest,
+				} {
+					err = tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg})
+					Ω(err).ShouldNot(HaveOccurred())
+					_, err = tarWriter.Write([]byte(contents))
+					Ω(err).ShouldNot(HaveOccurred())
+				}
+				Ω(tarWriter.Close()).Should(Succeed())
+
+				nexusclient.DockerBlobExistsStub = func(registryURL string, image string, digest string) (bool, error) {
+					return digest == models.DockerDigest([]byte(config)), nil
+				}
+			})
+
+			It("uploads the missing blobs", func() {
+				_, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.DockerBlobExistsCallCount()).Should(Equal(2))
+				Ω(nexusclient.UploadDockerBlobCallCount()).Should(Equal(1))
+
+				registryURL, image, digest, _ := nexusclient.UploadDockerBlobArgsForCall(0)
+				Ω(registryURL).Should(Equal("http://nexus-url.com/repository/docker-hosted"))
+				Ω(image).Should(Equal("team/api"))
+				Ω(digest).Should(Equal(models.DockerDigest([]byte("layer-contents"))))
+			})
+
+			It("pushes the manifest by digest and with each tag", func() {
+				response, err := command.Run(sourceDir, request)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(nexusclient.PutDockerManifestCallCount()).Should(Equal(3))
+				var references []string
+				for i := 0; i < 3; i++ {
+					_, _, reference, mediaType, _ := nexusclient.PutDockerManifestArgsForCall(i)
+					Ω(mediaType).Should(Equal(models.OCIImageManifestMediaType))
+					references = append(references, reference)
+				}
+				Ω(references).Should(Equal([]string{manifestDigest, "v1.4.2", "latest"}))
+
+				Ω(response.Version).Should(Equal(models.Version{
+					Path:   "v2/team/api/manifests/v1.4.2",
+					Digest: manifestDigest,
+				}))
+				Ω(response.Metadata).Should(ContainElement(models.MetadataPair{Name: "digest", Value: manifestDigest}))
+			})
+
+			It("errors without tags", func() {
+				request.Params.Tags = nil
+
+				_, err := command.Run(sourceDir, request)
+				Ω(err).Should(MatchError("tags or tags_file must be provided"))
+				Ω(nexusclient.PutDockerManifestCallCount()).Should(Equal(0))
+			})
+		})
 	})
 })
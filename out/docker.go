@@ -0,0 +1,260 @@
+package out
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// runDocker pushes the image of an OCI image layout through the registry v2
+// API, tagged with each of the tags
+func (command *Command) runDocker(sourceDir string, request Request) (Response, error) {
+	params := request.Params
+
+	image := params.ImageName
+	if image == "" {
+		image = request.Source.Docker.Image
+	}
+
+	tags, err := command.dockerTags(sourceDir, params)
+	if err != nil {
+		return Response{}, err
+	}
+
+	localPath, err := command.match(params.File, sourceDir)
+	if err != nil {
+		return Response{}, err
+	}
+
+	layoutDir := localPath
+	fileInfo, err := os.Stat(localPath)
+	if err != nil {
+		return Response{}, err
+	}
+	if !fileInfo.IsDir() {
+		layoutDir, err = ioutil.TempDir("", "oci-layout")
+		if err != nil {
+			return Response{}, err
+		}
+		defer os.RemoveAll(layoutDir)
+
+		err = extractTarball(localPath, layoutDir)
+		if err != nil {
+			return Response{}, err
+		}
+	}
+
+	descriptor, err := readOCIIndex(layoutDir)
+	if err != nil {
+		return Response{}, err
+	}
+
+	pusher := dockerPusher{
+		command:     command,
+		registryURL: request.Source.DockerRegistryURL(),
+		image:       image,
+		layoutDir:   layoutDir,
+	}
+
+	// The manifest is pushed once by digest, then with each tag
+	content, err := pusher.pushManifest(descriptor, descriptor.Digest)
+	if err != nil {
+		return Response{}, err
+	}
+	for _, tag := range tags {
+		err = command.nexusclient.PutDockerManifest(pusher.registryURL, image, tag, descriptor.MediaType, content)
+		if err != nil {
+			return Response{}, err
+		}
+	}
+
+	metadata := []models.MetadataPair{
+		{Name: "image", Value: image},
+		{Name: "digest", Value: descriptor.Digest},
+		{Name: "tags", Value: strings.Join(tags, " ")},
+	}
+
+	return Response{
+		Version: models.Version{
+			Path:   versions.DockerManifestPath(image, tags[0]),
+			Digest: descriptor.Digest,
+		},
+		Metadata: metadata,
+	}, nil
+}
+
+// dockerTags returns the tags of the params and of the tags file
+func (command *Command) dockerTags(sourceDir string, params Params) ([]string, error) {
+	tags := append([]string{}, params.Tags...)
+
+	if params.TagsFile != "" {
+		tagsPath, err := command.match(params.TagsFile, sourceDir)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := ioutil.ReadFile(tagsPath)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, strings.Fields(string(content))...)
+	}
+
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("tags or tags_file must be provided")
+	}
+
+	return tags, nil
+}
+
+// dockerPusher pushes the content of an OCI image layout
+type dockerPusher struct {
+	command     *Command
+	registryURL string
+	image       string
+	layoutDir   string
+}
+
+// pushManifest pushes the blobs and child manifests of a manifest before the
+// manifest itself, and returns its content
+func (pusher dockerPusher) pushManifest(descriptor models.DockerDescriptor, reference string) ([]byte, error) {
+	client := pusher.command.nexusclient
+
+	content, err := ioutil.ReadFile(pusher.blobPath(descriptor.Digest))
+	if err != nil {
+		return nil, err
+	}
+
+	if models.DockerDigest(content) != descriptor.Digest {
+		return nil, fmt.Errorf("digest mismatch for manifest %s", descriptor.Digest)
+	}
+
+	var manifest models.DockerManifest
+	err = json.Unmarshal(content, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest %s: %s", descriptor.Digest, err)
+	}
+
+	if manifest.IsIndex() {
+		for _, child := range manifest.Manifests {
+			_, err = pusher.pushManifest(child, child.Digest)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		blobs := manifest.Layers
+		if manifest.Config != nil {
+			blobs = append([]models.DockerDescriptor{*manifest.Config}, blobs...)
+		}
+
+		for _, blob := range blobs {
+			err = pusher.pushBlob(blob.Digest)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = client.PutDockerManifest(pusher.registryURL, pusher.image, reference, descriptor.MediaType, content)
+	return content, err
+}
+
+// pushBlob uploads a blob the registry doesn't have yet
+func (pusher dockerPusher) pushBlob(digest string) error {
+	client := pusher.command.nexusclient
+
+	exists, err := client.DockerBlobExists(pusher.registryURL, pusher.image, digest)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	return client.UploadDockerBlob(pusher.registryURL, pusher.image, digest, pusher.blobPath(digest))
+}
+
+func (pusher dockerPusher) blobPath(digest string) string {
+	algorithm, encoded, _ := strings.Cut(digest, ":")
+	return filepath.Join(pusher.layoutDir, "blobs", algorithm, encoded)
+}
+
+// readOCIIndex returns the descriptor of the single image of an OCI image layout
+func readOCIIndex(layoutDir string) (models.DockerDescriptor, error) {
+	content, err := ioutil.ReadFile(filepath.Join(layoutDir, "index.json"))
+	if err != nil {
+		return models.DockerDescriptor{}, fmt.Errorf("not an OCI image layout: %s", err)
+	}
+
+	var index models.DockerManifest
+	err = json.Unmarshal(content, &index)
+	if err != nil {
+		return models.DockerDescriptor{}, fmt.Errorf("reading index.json: %s", err)
+	}
+
+	if len(index.Manifests) != 1 {
+		return models.DockerDescriptor{}, fmt.Errorf("expected a single image in the OCI image layout, found %d", len(index.Manifests))
+	}
+
+	descriptor := index.Manifests[0]
+	if descriptor.MediaType == "" {
+		descriptor.MediaType = models.OCIImageManifestMediaType
+	}
+
+	return descriptor, nil
+}
+
+// extractTarball extracts the regular files of a tarball in a directory
+func extractTarball(tarballPath string, destinationDir string) error {
+	tarball, err := os.Open(tarballPath)
+	if err != nil {
+		return err
+	}
+	defer tarball.Close()
+
+	tr := tar.NewReader(tarball)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading tarball %s: %s", tarballPath, err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || strings.HasPrefix(name, "..") {
+			return fmt.Errorf("invalid path %s in tarball %s", header.Name, tarballPath)
+		}
+
+		localPath := filepath.Join(destinationDir, name)
+		err = os.MkdirAll(filepath.Dir(localPath), 0755)
+		if err != nil {
+			return err
+		}
+
+		localFile, err := os.Create(localPath)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(localFile, tr)
+		localFile.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package out
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+	"gopkg.in/yaml.v3"
+)
+
+// runHelm uploads a packaged chart through the components API
+func (command *Command) runHelm(sourceDir string, request Request) (Response, error) {
+	helm := request.Source.Helm
+
+	localPath, err := command.match(request.Params.File, sourceDir)
+	if err != nil {
+		return Response{}, err
+	}
+
+	chart, err := readHelmChart(localPath)
+	if err != nil {
+		return Response{}, err
+	}
+
+	if chart.Name != helm.Chart {
+		return Response{}, fmt.Errorf("helm chart %s does not match the source %s", chart.Name, helm.Chart)
+	}
+
+	err = command.nexusclient.UploadComponent(
+		request.Source.Repository,
+		map[string]string{},
+		map[string]string{"helm.asset": localPath},
+	)
+	if err != nil {
+		return Response{}, err
+	}
+
+	remotePath := versions.HelmChartPath(helm, chart.Version)
+
+	metadata := command.metadata(request.Source.Repository, filepath.Base(localPath), remotePath)
+	metadata = append(metadata, models.MetadataPair{
+		Name:  "version",
+		Value: chart.Version,
+	})
+
+	return Response{
+		Version:  models.Version{Path: remotePath},
+		Metadata: metadata,
+	}, nil
+}
+
+// readHelmChart returns the Chart.yaml of a packaged chart, stored in the
+// folder of the chart at the root of the tarball
+func readHelmChart(localPath string) (models.HelmChartVersion, error) {
+	var chart models.HelmChartVersion
+
+	content, err := readTarballFile(localPath, func(name string) bool {
+		return path.Base(name) == "Chart.yaml" && path.Dir(path.Dir(name)) == "."
+	})
+	if err != nil {
+		return chart, err
+	}
+
+	err = yaml.Unmarshal(content, &chart)
+	return chart, err
+}
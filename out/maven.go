@@ -0,0 +1,207 @@
+package out
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// runMaven deploys the artifact and its additional assets as a Maven 2 component
+func (command *Command) runMaven(sourceDir string, request Request) (Response, error) {
+	maven := request.Source.Maven
+	params := request.Params
+
+	localPath, err := command.match(params.File, sourceDir)
+	if err != nil {
+		return Response{}, err
+	}
+
+	fields := map[string]string{}
+	assets := map[string]string{}
+	addAsset := func(assetPath string, extension string, classifier string) {
+		name := "maven2.asset" + strconv.Itoa(len(assets)+1)
+		assets[name] = assetPath
+		fields[name+".extension"] = extension
+		if classifier != "" {
+			fields[name+".classifier"] = classifier
+		}
+	}
+
+	if params.PomFile != "" {
+		pomPath, err := command.match(params.PomFile, sourceDir)
+		if err != nil {
+			return Response{}, err
+		}
+
+		pom, err := readPom(pomPath)
+		if err != nil {
+			return Response{}, err
+		}
+
+		if pom.GroupID != maven.GroupID || pom.ArtifactID != maven.ArtifactID {
+			return Response{}, fmt.Errorf("pom coordinates %s:%s do not match the source %s:%s", pom.GroupID, pom.ArtifactID, maven.GroupID, maven.ArtifactID)
+		}
+
+		maven.Version = pom.Version
+		fields["maven2.generate-pom"] = "false"
+		addAsset(pomPath, "pom", "")
+	} else {
+		if params.VersionFile != "" {
+			maven.Version, err = command.readVersionFile(params.VersionFile, sourceDir)
+			if err != nil {
+				return Response{}, err
+			}
+		}
+
+		if maven.Version == "" {
+			return Response{}, fmt.Errorf("version_file, pom_file or maven.version must be provided")
+		}
+
+		fields["maven2.groupId"] = maven.GroupID
+		fields["maven2.artifactId"] = maven.ArtifactID
+		fields["maven2.version"] = maven.Version
+		fields["maven2.generate-pom"] = "true"
+	}
+
+	addAsset(localPath, maven.FileExtension(), maven.Classifier)
+
+	for _, asset := range params.Assets {
+		assetPath, err := command.match(asset.File, sourceDir)
+		if err != nil {
+			return Response{}, err
+		}
+
+		extension := asset.Extension
+		if extension == "" {
+			extension = strings.TrimPrefix(filepath.Ext(assetPath), ".")
+		}
+		addAsset(assetPath, extension, asset.Classifier)
+	}
+
+	err = command.nexusclient.UploadComponent(request.Source.Repository, fields, assets)
+	if err != nil {
+		return Response{}, err
+	}
+
+	remotePath, value, err := command.mavenDeployedPath(request.Source, maven, localPath)
+	if err != nil {
+		return Response{}, err
+	}
+
+	version := models.Version{
+		Path: remotePath,
+		GAV:  versions.MavenGAV(maven, value),
+	}
+
+	metadata := command.metadata(request.Source.Repository, filepath.Base(localPath), remotePath)
+	metadata = append(metadata, models.MetadataPair{
+		Name:  "gav",
+		Value: version.GAV,
+	})
+
+	return Response{
+		Version:  version,
+		Metadata: metadata,
+	}, nil
+}
+
+// mavenDeployedPath returns the path and version of the deployed artifact, a
+// snapshot is resolved to the newest timestamped build whose sha1 matches the
+// uploaded file, as another build may have been deployed since
+func (command *Command) mavenDeployedPath(source models.Source, maven models.MavenSource, localPath string) (string, string, error) {
+	if !maven.IsSnapshot() {
+		return versions.MavenFilePath(maven, maven.Version, maven.Version), maven.Version, nil
+	}
+
+	source.Maven = maven
+	extractions, err := versions.ListMavenVersions(command.nexusclient, source)
+	if err != nil {
+		return "", "", err
+	}
+	sort.Sort(extractions)
+
+	checksum, err := fileSha1(localPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	for i := len(extractions) - 1; i >= 0; i-- {
+		content, err := command.nexusclient.GetFile(source.Repository, extractions[i].Path+".sha1")
+		if err != nil {
+			continue
+		}
+
+		// The checksum file may be followed by the name of the file
+		fields := strings.Fields(string(content))
+		if len(fields) > 0 && strings.EqualFold(fields[0], checksum) {
+			return extractions[i].Path, extractions[i].VersionNumber, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("the uploaded build of %s was not found in the maven metadata", maven.Version)
+}
+
+// fileSha1 returns the hex encoded sha1 of a file
+func fileSha1(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha1.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (command *Command) readVersionFile(pattern string, sourceDir string) (string, error) {
+	versionPath, err := command.match(pattern, sourceDir)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := ioutil.ReadFile(versionPath)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+func readPom(pomPath string) (models.MavenPom, error) {
+	var pom models.MavenPom
+
+	content, err := ioutil.ReadFile(pomPath)
+	if err != nil {
+		return pom, err
+	}
+
+	err = xml.Unmarshal(content, &pom)
+	if err != nil {
+		return pom, fmt.Errorf("reading pom %s: %s", pomPath, err)
+	}
+
+	// Coordinates may be inherited from the parent
+	if pom.GroupID == "" {
+		pom.GroupID = pom.Parent.GroupID
+	}
+	if pom.Version == "" {
+		pom.Version = pom.Parent.Version
+	}
+
+	return pom, nil
+}
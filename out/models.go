@@ -10,7 +10,29 @@ type Request struct {
 
 // Params struct for the Out command
 type Params struct {
-	File string `json:"file"`
+	File        string       `json:"file"`
+	PomFile     string       `json:"pom_file"`
+	VersionFile string       `json:"version_file"`
+	Assets      []MavenAsset `json:"assets"`
+
+	// raw files only, defaults to the group of the source and required with
+	// selectors
+	Group string `json:"group"`
+
+	// yum packages only, defaults to the directory of the source
+	Directory string `json:"directory"`
+
+	// Docker images only
+	ImageName string   `json:"image_name"`
+	Tags      []string `json:"tags"`
+	TagsFile  string   `json:"tags_file"`
+}
+
+// MavenAsset struct for an additional file of a Maven component
+type MavenAsset struct {
+	File       string `json:"file"`
+	Extension  string `json:"extension"`
+	Classifier string `json:"classifier"`
 }
 
 // Response struct of the Out command
@@ -0,0 +1,103 @@
+package out
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// npm pack stores the manifest under the package folder of the tarball
+const npmManifestPath = "package/package.json"
+
+// runNpm publishes a packed npm tarball through the components API
+func (command *Command) runNpm(sourceDir string, request Request) (Response, error) {
+	npm := request.Source.Npm
+
+	localPath, err := command.match(request.Params.File, sourceDir)
+	if err != nil {
+		return Response{}, err
+	}
+
+	manifest, err := readNpmManifest(localPath)
+	if err != nil {
+		return Response{}, err
+	}
+
+	if manifest.Name != npm.Package {
+		return Response{}, fmt.Errorf("npm package %s does not match the source %s", manifest.Name, npm.Package)
+	}
+
+	err = command.nexusclient.UploadComponent(
+		request.Source.Repository,
+		map[string]string{},
+		map[string]string{"npm.asset": localPath},
+	)
+	if err != nil {
+		return Response{}, err
+	}
+
+	remotePath := versions.NpmTarballPath(npm, manifest.Version)
+
+	metadata := command.metadata(request.Source.Repository, filepath.Base(localPath), remotePath)
+	metadata = append(metadata, models.MetadataPair{
+		Name:  "version",
+		Value: manifest.Version,
+	})
+
+	return Response{
+		Version:  models.Version{Path: remotePath},
+		Metadata: metadata,
+	}, nil
+}
+
+func readNpmManifest(localPath string) (models.NpmPackageVersion, error) {
+	var manifest models.NpmPackageVersion
+
+	content, err := readTarballFile(localPath, func(name string) bool {
+		return name == npmManifestPath
+	})
+	if err != nil {
+		return manifest, err
+	}
+
+	err = json.Unmarshal(content, &manifest)
+	return manifest, err
+}
+
+// readTarballFile returns the content of the first file of a gzipped tarball
+// whose name is matched
+func readTarballFile(localPath string, match func(name string) bool) ([]byte, error) {
+	localFile, err := os.Open(localPath)
+	if err != nil {
+		return nil, err
+	}
+	defer localFile.Close()
+
+	gzipReader, err := gzip.NewReader(localFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading tarball %s: %s", localPath, err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("file not found in tarball %s", localPath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading tarball %s: %s", localPath, err)
+		}
+
+		if match(header.Name) {
+			return io.ReadAll(tarReader)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package out
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// runNuGet pushes a .nupkg through the components API
+func (command *Command) runNuGet(sourceDir string, request Request) (Response, error) {
+	nuget := request.Source.NuGet
+
+	localPath, err := command.match(request.Params.File, sourceDir)
+	if err != nil {
+		return Response{}, err
+	}
+
+	nuspec, err := readNuspec(localPath)
+	if err != nil {
+		return Response{}, err
+	}
+
+	if !strings.EqualFold(nuspec.Metadata.ID, nuget.Package) {
+		return Response{}, fmt.Errorf("nuget package %s does not match the source %s", nuspec.Metadata.ID, nuget.Package)
+	}
+
+	err = command.nexusclient.UploadComponent(
+		request.Source.Repository,
+		map[string]string{},
+		map[string]string{"nuget.asset": localPath},
+	)
+	if err != nil {
+		return Response{}, err
+	}
+
+	remotePath := versions.NuGetPackagePath(nuget, nuspec.Metadata.Version)
+
+	metadata := command.metadata(request.Source.Repository, filepath.Base(localPath), remotePath)
+	metadata = append(metadata, models.MetadataPair{
+		Name:  "version",
+		Value: nuspec.Metadata.Version,
+	})
+
+	return Response{
+		Version:  models.Version{Path: remotePath},
+		Metadata: metadata,
+	}, nil
+}
+
+// readNuspec returns the .nuspec stored at the root of a .nupkg
+func readNuspec(localPath string) (models.NuGetNuspec, error) {
+	var nuspec models.NuGetNuspec
+
+	archive, err := zip.OpenReader(localPath)
+	if err != nil {
+		return nuspec, fmt.Errorf("reading nupkg %s: %s", localPath, err)
+	}
+	defer archive.Close()
+
+	for _, file := range archive.File {
+		if path.Dir(file.Name) != "." || path.Ext(file.Name) != ".nuspec" {
+			continue
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			return nuspec, err
+		}
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nuspec, err
+		}
+
+		err = xml.Unmarshal(content, &nuspec)
+		if err != nil {
+			return nuspec, fmt.Errorf("reading nuspec of %s: %s", localPath, err)
+		}
+
+		return nuspec, nil
+	}
+
+	return nuspec, fmt.Errorf("nuspec not found in nupkg %s", localPath)
+}
@@ -0,0 +1,53 @@
+package out
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// runPypi uploads a wheel or sdist through the components API
+func (command *Command) runPypi(sourceDir string, request Request) (Response, error) {
+	pypi := request.Source.Pypi
+
+	localPath, err := command.match(request.Params.File, sourceDir)
+	if err != nil {
+		return Response{}, err
+	}
+
+	localFileName := filepath.Base(localPath)
+	distribution, ok := versions.ParsePypiFilename(localFileName)
+	if !ok {
+		return Response{}, fmt.Errorf("not a wheel or sdist: %s", localFileName)
+	}
+
+	name := versions.NormalizePypiName(distribution.Name)
+	if name != versions.NormalizePypiName(pypi.Package) {
+		return Response{}, fmt.Errorf("pypi package %s does not match the source %s", distribution.Name, pypi.Package)
+	}
+
+	err = command.nexusclient.UploadComponent(
+		request.Source.Repository,
+		map[string]string{},
+		map[string]string{"pypi.asset": localPath},
+	)
+	if err != nil {
+		return Response{}, err
+	}
+
+	remotePath := path.Join("packages", name, distribution.VersionNumber, localFileName)
+
+	metadata := command.metadata(request.Source.Repository, localFileName, remotePath)
+	metadata = append(metadata, models.MetadataPair{
+		Name:  "version",
+		Value: distribution.VersionNumber,
+	})
+
+	return Response{
+		Version:  models.Version{Path: remotePath},
+		Metadata: metadata,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package out
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+// runYum uploads an .rpm through the components API in the directory
+func (command *Command) runYum(sourceDir string, request Request) (Response, error) {
+	yum := request.Source.Yum
+
+	localPath, err := command.match(request.Params.File, sourceDir)
+	if err != nil {
+		return Response{}, err
+	}
+
+	directory := request.Params.Directory
+	if directory == "" {
+		directory = yum.Directory
+	}
+	directory = strings.Trim(directory, "/")
+
+	localFileName := filepath.Base(localPath)
+	remotePath := path.Join(directory, localFileName)
+
+	extraction, ok := versions.ExtractYum(remotePath, yum)
+	if !ok {
+		return Response{}, fmt.Errorf("%s is not named as an .rpm of yum package %s", localFileName, yum.Package)
+	}
+
+	err = command.nexusclient.UploadComponent(
+		request.Source.Repository,
+		map[string]string{
+			"yum.directory":      directory,
+			"yum.asset.filename": localFileName,
+		},
+		map[string]string{"yum.asset": localPath},
+	)
+	if err != nil {
+		return Response{}, err
+	}
+
+	metadata := command.metadata(request.Source.Repository, localFileName, remotePath)
+	metadata = append(metadata, models.MetadataPair{
+		Name:  "version",
+		Value: extraction.VersionNumber,
+	})
+
+	return Response{
+		Version:  models.Version{Path: remotePath},
+		Metadata: metadata,
+	}, nil
+}
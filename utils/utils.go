@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/mitchellh/colorstring"
 	"github.com/sirupsen/logrus"
@@ -72,3 +73,39 @@ func (l *StandardLogger) LogHTTPRequest(requestType string, requestURL string) {
 		}).Info("Executing Http Request")
 	}
 }
+
+// MaxConcurrentRequests is the number of requests sent at once by
+// ForEachConcurrently
+const MaxConcurrentRequests = 8
+
+// ForEachConcurrently calls fn with each index below count from a pool of
+// MaxConcurrentRequests goroutines, the error of the lowest index is returned
+func ForEachConcurrently(count int, fn func(i int) error) error {
+	errs := make([]error, count)
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < MaxConcurrentRequests && worker < count; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
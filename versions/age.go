@@ -0,0 +1,69 @@
+package versions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+// FilterAge returns the Extractions whose asset was uploaded within the
+// min_age and max_age of the provided Source and, when required, has a sha256
+// checksum. Assets without an upload time are skipped, their age being unknown
+func FilterAge(extractions Extractions, source models.Source, now time.Time, l *utils.StandardLogger) (Extractions, error) {
+	minAge, err := parseAge(source.MinAge, "min_age")
+	if err != nil {
+		return nil, err
+	}
+	maxAge, err := parseAge(source.MaxAge, "max_age")
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make(Extractions, 0, len(extractions))
+	for _, extraction := range extractions {
+		if source.RequireChecksum && extraction.Sha256 == "" {
+			l.LogSimpleMessage("In FilterAge skipping '%s' which has no checksum yet", extraction.Path)
+			continue
+		}
+
+		if source.MinAge == "" && source.MaxAge == "" {
+			filtered = append(filtered, extraction)
+			continue
+		}
+
+		if extraction.LastModified.IsZero() {
+			l.LogSimpleMessage("In FilterAge skipping '%s' whose upload time is unknown", extraction.Path)
+			continue
+		}
+
+		age := now.Sub(extraction.LastModified)
+		if source.MinAge != "" && age < minAge {
+			l.LogSimpleMessage("In FilterAge skipping '%s' uploaded %s ago, within min_age", extraction.Path, age)
+			continue
+		}
+
+		if source.MaxAge != "" && age > maxAge {
+			l.LogSimpleMessage("In FilterAge skipping '%s' uploaded %s ago, beyond max_age", extraction.Path, age)
+			continue
+		}
+
+		filtered = append(filtered, extraction)
+	}
+
+	return filtered, nil
+}
+
+func parseAge(value string, field string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %s", field, err)
+	}
+
+	return age, nil
+}
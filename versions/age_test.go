@@ -0,0 +1,54 @@
+package versions_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("FilterAge", func() {
+	var source models.Source
+	var extractions versions.Extractions
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		source = models.Source{}
+		extractions = versions.Extractions{
+			{Path: "app-1.0.0.tgz", LastModified: now.Add(-72 * time.Hour), Sha256: "aaa"},
+			{Path: "app-1.1.0.tgz", LastModified: now.Add(-time.Hour)},
+			{Path: "app-1.2.0.tgz", LastModified: now.Add(-5 * time.Minute), Sha256: "bbb"},
+			{Path: "app-1.3.0.tgz", Sha256: "ccc"},
+		}
+	})
+
+	filter := func() []string {
+		filtered, err := versions.FilterAge(extractions, source, now, utils.NewLogger(false))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var paths []string
+		for _, extraction := range filtered {
+			paths = append(paths, extraction.Path)
+		}
+		return paths
+	}
+
+	It("skips the assets uploaded within min_age or of unknown age", func() {
+		source.MinAge = "10m"
+		Ω(filter()).Should(Equal([]string{"app-1.0.0.tgz", "app-1.1.0.tgz"}))
+	})
+
+	It("skips the assets uploaded before max_age", func() {
+		source.MaxAge = "24h"
+		Ω(filter()).Should(Equal([]string{"app-1.1.0.tgz", "app-1.2.0.tgz"}))
+	})
+
+	It("skips the assets without checksum", func() {
+		source.RequireChecksum = true
+		Ω(filter()).Should(Equal([]string{"app-1.0.0.tgz", "app-1.2.0.tgz", "app-1.3.0.tgz"}))
+	})
+})
@@ -0,0 +1,249 @@
+package versions
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/cppforlife/go-semi-semantic/version"
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+// DpkgVersion struct is a Debian package version, [epoch:]upstream[-revision]
+type DpkgVersion struct {
+	Epoch    int
+	Upstream string
+	Revision string
+}
+
+// NewDpkgVersion parses a Debian package version
+func NewDpkgVersion(value string) (DpkgVersion, error) {
+	var ver DpkgVersion
+
+	if index := strings.Index(value, ":"); index >= 0 {
+		epoch, err := strconv.Atoi(value[:index])
+		if err != nil {
+			return ver, fmt.Errorf("'%s' is not a valid Debian version: invalid epoch", value)
+		}
+		ver.Epoch = epoch
+		value = value[index+1:]
+	}
+
+	if index := strings.LastIndex(value, "-"); index >= 0 {
+		ver.Revision = value[index+1:]
+		value = value[:index]
+	}
+
+	if value == "" || value[0] < '0' || value[0] > '9' {
+		return ver, fmt.Errorf("'%s' is not a valid Debian version: upstream version must start with a digit", value)
+	}
+	ver.Upstream = value
+
+	return ver, nil
+}
+
+// Compare returns -1, 0 or 1 when the version is lower, equal or greater
+// than the other version, following the dpkg ordering
+func (v DpkgVersion) Compare(comparable Comparable) int {
+	other := comparable.(DpkgVersion)
+	if c := compareInts([]int{v.Epoch}, []int{other.Epoch}); c != 0 {
+		return c
+	}
+
+	if c := dpkgVerrevcmp(v.Upstream, other.Upstream); c != 0 {
+		return c
+	}
+
+	return dpkgVerrevcmp(v.Revision, other.Revision)
+}
+
+// dpkgOrder is the weight of a character in the non-digit parts of a version,
+// a tilde sorts before anything, even the end of the part
+func dpkgOrder(s string, i int) int {
+	switch {
+	case i >= len(s) || isDigit(s[i]):
+		return 0
+	case isLetter(s[i]):
+		return int(s[i])
+	case s[i] == '~':
+		return -1
+	}
+
+	return int(s[i]) + 256
+}
+
+// dpkgVerrevcmp compares alternating non-digit and digit parts, as dpkg does
+func dpkgVerrevcmp(a string, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			if c := compareInts([]int{dpkgOrder(a, i)}, []int{dpkgOrder(b, j)}); c != 0 {
+				return c
+			}
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+
+		firstDiff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = compareInts([]int{int(a[i])}, []int{int(b[j])})
+			}
+			i++
+			j++
+		}
+
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+
+	return 0
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// ParseAptPackages returns the stanzas of a Packages index
+func ParseAptPackages(content []byte) []models.AptPackage {
+	var packages []models.AptPackage
+	var current models.AptPackage
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			if current.Package != "" {
+				packages = append(packages, current)
+			}
+			current = models.AptPackage{}
+			continue
+		}
+
+		// Continuation lines of multiline fields
+		if line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+
+		field, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch field {
+		case "Package":
+			current.Package = value
+		case "Version":
+			current.Version = value
+		case "Architecture":
+			current.Architecture = value
+		case "Filename":
+			current.Filename = value
+		case "SHA256":
+			current.SHA256 = value
+		}
+	}
+
+	if current.Package != "" {
+		packages = append(packages, current)
+	}
+
+	return packages
+}
+
+// GetAptPackages returns the versions of the package listed in the Packages index
+func GetAptPackages(client nexusresource.NexusClient, repositoryName string, apt models.AptSource) ([]models.AptPackage, error) {
+	content, err := client.GetFile(repositoryName, apt.PackagesPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []models.AptPackage
+	for _, aptPackage := range ParseAptPackages(content) {
+		if aptPackage.Package == apt.Package {
+			packages = append(packages, aptPackage)
+		}
+	}
+
+	return packages, nil
+}
+
+// ExtractApt a version from the pool path of a .deb, named
+// <package>_<version>_<architecture>.deb
+func ExtractApt(debPath string, apt models.AptSource) (Extraction, bool) {
+	filename, err := url.PathUnescape(path.Base(debPath))
+	if err != nil || !strings.HasSuffix(filename, ".deb") {
+		return Extraction{}, false
+	}
+
+	parts := strings.Split(strings.TrimSuffix(filename, ".deb"), "_")
+	if len(parts) != 3 || parts[0] != apt.Package {
+		return Extraction{}, false
+	}
+
+	dpkg, err := NewDpkgVersion(parts[1])
+	if err != nil {
+		return Extraction{}, false
+	}
+
+	// Only used for display, the ordering relies on the dpkg version
+	ver, _ := version.NewVersionFromString(parts[1])
+
+	return Extraction{
+		Path:          debPath,
+		Version:       ver,
+		VersionNumber: parts[1],
+		Comparable:    dpkg,
+	}, true
+}
+
+// getAptVersions returns the Extractions of a Debian package listed in the
+// Packages index of the distribution
+func getAptVersions(client nexusresource.NexusClient, source models.Source) (Extractions, error) {
+	l := utils.NewLogger(source.Debug)
+	l.LogSimpleMessage("In getAptVersions reading '%s'", source.Apt.PackagesPath())
+
+	packages, err := GetAptPackages(client, source.Repository, source.Apt)
+	if err != nil {
+		return nil, fmt.Errorf("reading apt packages: %s", err)
+	}
+
+	var extractions = make(Extractions, 0, len(packages))
+	for _, aptPackage := range packages {
+		extraction, ok := ExtractApt(aptPackage.Filename, source.Apt)
+
+		if ok {
+			extractions = append(extractions, extraction)
+		}
+	}
+
+	l.LogSimpleMessage("In getAptVersions extracted '%d' versions from the Packages index", len(extractions))
+
+	return extractions, nil
+}
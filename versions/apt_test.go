@@ -0,0 +1,80 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("DpkgVersion", func() {
+	compare := func(a string, b string) int {
+		x, err := versions.NewDpkgVersion(a)
+		Ω(err).ShouldNot(HaveOccurred())
+		y, err := versions.NewDpkgVersion(b)
+		Ω(err).ShouldNot(HaveOccurred())
+		return x.Compare(y)
+	}
+
+	It("orders by epoch first", func() {
+		Ω(compare("1:1.0", "2.0")).Should(Equal(1))
+		Ω(compare("0:1.0", "1.0")).Should(Equal(0))
+	})
+
+	It("orders numeric parts numerically", func() {
+		Ω(compare("1.10", "1.9")).Should(Equal(1))
+		Ω(compare("1.01", "1.1")).Should(Equal(0))
+	})
+
+	It("orders a tilde before anything, even the end", func() {
+		Ω(compare("1.0~rc1", "1.0")).Should(Equal(-1))
+		Ω(compare("1.0~~", "1.0~")).Should(Equal(-1))
+		Ω(compare("1.0~rc1", "1.0~rc2")).Should(Equal(-1))
+	})
+
+	It("orders letters before other characters", func() {
+		Ω(compare("1.0a", "1.0+")).Should(Equal(-1))
+		Ω(compare("1.0+b1", "1.0")).Should(Equal(1))
+	})
+
+	It("orders by revision last", func() {
+		Ω(compare("1.0-2", "1.0-10")).Should(Equal(-1))
+		Ω(compare("1.0-1ubuntu1", "1.0-1")).Should(Equal(1))
+	})
+})
+
+var _ = Describe("ExtractApt", func() {
+	apt := models.AptSource{Package: "billing-agent", Distribution: "jammy"}
+
+	It("parses the stanzas of a Packages index", func() {
+		packages := versions.ParseAptPackages([]byte(`Package: billing-agent
+Version: 1.2.0-1
+Architecture: amd64
+Description: Billing agent
+ with a multiline description
+Filename: pool/b/billing-agent/billing-agent_1.2.0-1_amd64.deb
+SHA256: 4a1f
+
+Package: other
+Version: 2.0
+Filename: pool/o/other/other_2.0_all.deb
+`))
+
+		Ω(packages).Should(Equal([]models.AptPackage{
+			{Package: "billing-agent", Version: "1.2.0-1", Architecture: "amd64", Filename: "pool/b/billing-agent/billing-agent_1.2.0-1_amd64.deb", SHA256: "4a1f"},
+			{Package: "other", Version: "2.0", Filename: "pool/o/other/other_2.0_all.deb"},
+		}))
+	})
+
+	It("extracts the version of a .deb", func() {
+		result, ok := versions.ExtractApt("pool/b/billing-agent/billing-agent_1%3a1.2.0-1_amd64.deb", apt)
+		Ω(ok).Should(BeTrue())
+		Ω(result.VersionNumber).Should(Equal("1:1.2.0-1"))
+	})
+
+	It("doesn't extract other packages", func() {
+		_, ok := versions.ExtractApt("pool/b/billing/billing_1.2.0-1_amd64.deb", apt)
+		Ω(ok).Should(BeFalse())
+	})
+})
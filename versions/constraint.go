@@ -0,0 +1,32 @@
+package versions
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+// FilterConstraint returns the Extractions whose version satisfies the semver
+// range constraint, versions which aren't semantic versions are skipped
+func FilterConstraint(extractions Extractions, versionConstraint string, l *utils.StandardLogger) (Extractions, error) {
+	constraint, err := semver.NewConstraint(versionConstraint)
+	if err != nil {
+		return nil, fmt.Errorf("parsing version_constraint: %s", err)
+	}
+
+	filtered := make(Extractions, 0, len(extractions))
+	for _, extraction := range extractions {
+		ver, err := semver.NewVersion(extraction.VersionNumber)
+		if err != nil {
+			l.LogSimpleMessage("In FilterConstraint skipping '%s' which is not a semantic version", extraction.VersionNumber)
+			continue
+		}
+
+		if constraint.Check(ver) {
+			filtered = append(filtered, extraction)
+		}
+	}
+
+	return filtered, nil
+}
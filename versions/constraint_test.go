@@ -0,0 +1,55 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/utils"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("FilterConstraint", func() {
+	var extractions versions.Extractions
+
+	BeforeEach(func() {
+		extractions = nil
+		for _, path := range []string{"2.2.9", "2.3.0", "2.4.0-rc.1", "2.10.1", "3.0.0", "3.1.0", "4.0.0", "nightly"} {
+			extraction, err := versions.Extract("app-"+path, "app-(.*)")
+			Ω(err).ShouldNot(HaveOccurred())
+			extractions = append(extractions, extraction)
+		}
+	})
+
+	filter := func(constraint string) []string {
+		filtered, err := versions.FilterConstraint(extractions, constraint, utils.NewLogger(false))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var versionNumbers []string
+		for _, extraction := range filtered {
+			versionNumbers = append(versionNumbers, extraction.VersionNumber)
+		}
+		return versionNumbers
+	}
+
+	It("filters on a range", func() {
+		Ω(filter(">=2.3.0 <3.0.0")).Should(Equal([]string{"2.3.0", "2.10.1"}))
+	})
+
+	It("filters on tilde and caret ranges", func() {
+		Ω(filter("~2.3")).Should(Equal([]string{"2.3.0"}))
+		Ω(filter("^3.0.0")).Should(Equal([]string{"3.0.0", "3.1.0"}))
+	})
+
+	It("filters on alternatives", func() {
+		Ω(filter("~2.2 || >=4")).Should(Equal([]string{"2.2.9", "4.0.0"}))
+	})
+
+	It("includes pre-releases only when the range does", func() {
+		Ω(filter(">=2.4.0-0 <2.5.0-0")).Should(Equal([]string{"2.4.0-rc.1"}))
+	})
+
+	It("errors on an invalid range", func() {
+		_, err := versions.FilterConstraint(extractions, ">=2.3.0 <", utils.NewLogger(false))
+		Ω(err).Should(MatchError(HavePrefix("parsing version_constraint: ")))
+	})
+})
@@ -0,0 +1,95 @@
+package versions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+// DockerManifestPath returns the repository path of the manifest of an image tag
+func DockerManifestPath(image string, tag string) string {
+	return "v2/" + image + "/manifests/" + tag
+}
+
+// DockerTag returns the tag of the manifest path of an image
+func DockerTag(manifestPath string, image string) (string, bool) {
+	prefix := DockerManifestPath(image, "")
+	if !strings.HasPrefix(manifestPath, prefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(manifestPath, prefix), true
+}
+
+// ExtractDocker a version from the manifest path of an image tag, the regexp
+// of the Source is matched against the tag
+func ExtractDocker(manifestPath string, source models.Source) (Extraction, error) {
+	tag, ok := DockerTag(manifestPath, source.Docker.Image)
+	if !ok {
+		return Extraction{}, ErrNoMatch
+	}
+
+	matched, err := Match([]string{tag}, source.Regexp)
+	if err != nil {
+		return Extraction{}, err
+	}
+	if len(matched) == 0 {
+		return Extraction{}, ErrNoMatch
+	}
+
+	extraction, err := ExtractScheme(tag, source)
+	extraction.Path = manifestPath
+	return extraction, err
+}
+
+// getDockerVersions returns the Extractions of the tags of an image, listed
+// through the registry v2 API or the search API
+func getDockerVersions(client nexusresource.NexusClient, source models.Source) (Extractions, []skippedPath, error) {
+	l := utils.NewLogger(source.Debug)
+
+	var tags []string
+	if source.Docker.SearchAPI {
+		l.LogSimpleMessage("In getDockerVersions searching tags for image '%s'", source.Docker.Image)
+		items, err := client.SearchComponents(source.Repository, map[string]string{
+			"format": models.FormatDocker,
+			"name":   source.Docker.Image,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("searching tags: %s", err)
+		}
+
+		for _, item := range items {
+			if item.Name == source.Docker.Image {
+				tags = append(tags, item.Version)
+			}
+		}
+	} else {
+		l.LogSimpleMessage("In getDockerVersions listing tags for image '%s'", source.Docker.Image)
+		var err error
+		tags, err = client.ListDockerTags(source.DockerRegistryURL(), source.Docker.Image)
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing tags: %s", err)
+		}
+	}
+
+	var extractions = make(Extractions, 0, len(tags))
+	var skipped []skippedPath
+	for _, tag := range tags {
+		extraction, err := ExtractDocker(DockerManifestPath(source.Docker.Image, tag), source)
+		if err == ErrNoMatch {
+			continue
+		} else if err != nil {
+			skipped = append(skipped, skippedPath{tag, err})
+			continue
+		}
+
+		extractions = append(extractions, extraction)
+	}
+
+	l.LogSimpleMessage("In getDockerVersions extracted '%d' versions from the tags", len(extractions))
+
+	return extractions, skipped, nil
+}
@@ -0,0 +1,33 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("ExtractDocker", func() {
+	source := models.Source{
+		Regexp: `v(\d+\.\d+\.\d+)`,
+		Docker: models.DockerSource{Image: "team/api"},
+	}
+
+	It("extracts the version of a tag", func() {
+		result, err := versions.ExtractDocker("v2/team/api/manifests/v1.4.2", source)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(result.Path).Should(Equal("v2/team/api/manifests/v1.4.2"))
+		Ω(result.VersionNumber).Should(Equal("1.4.2"))
+	})
+
+	It("doesn't extract tags not matching the regexp", func() {
+		_, err := versions.ExtractDocker("v2/team/api/manifests/latest", source)
+		Ω(err).Should(Equal(versions.ErrNoMatch))
+	})
+
+	It("doesn't extract tags of other images", func() {
+		_, err := versions.ExtractDocker("v2/team/web/manifests/v1.4.2", source)
+		Ω(err).Should(Equal(versions.ErrNoMatch))
+	})
+})
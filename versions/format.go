@@ -0,0 +1,43 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+)
+
+// RepositoryFormat returns the format of a repository, an error when the
+// resource doesn't support it
+func RepositoryFormat(repository models.Repository) (string, error) {
+	if sliceIndex(models.Formats, repository.Format) < 0 {
+		return "", fmt.Errorf("repository '%s' has format '%s' which is not supported", repository.Name, repository.Format)
+	}
+
+	return repository.Format, nil
+}
+
+// ResolveFormat returns the Source with its format detected from the
+// repository when it isn't configured, validated for that format
+func ResolveFormat(client nexusresource.NexusClient, source models.Source) (models.Source, error) {
+	if source.Format != "" {
+		return source, nil
+	}
+
+	repository, err := client.GetRepository(source.Repository)
+	if err != nil {
+		return source, fmt.Errorf("detecting the format of repository '%s': %s", source.Repository, err)
+	}
+
+	source.Format, err = RepositoryFormat(repository)
+	if err != nil {
+		return source, err
+	}
+
+	if ok, message := source.IsValid(); !ok {
+		return source, errors.New(message)
+	}
+
+	return source, nil
+}
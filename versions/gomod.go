@@ -0,0 +1,125 @@
+package versions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cppforlife/go-semi-semantic/version"
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// GoModulePath returns the GOPROXY path of a file of the module, the module
+// path is case-encoded as per the GOPROXY protocol
+func GoModulePath(goModule models.GoSource, name string) string {
+	escaped, err := module.EscapePath(goModule.Module)
+	if err != nil {
+		escaped = goModule.Module
+	}
+
+	return escaped + "/" + name
+}
+
+// GoModuleVersionPath returns the GOPROXY path of a file of a module version,
+// extension is one of .info, .mod or .zip
+func GoModuleVersionPath(goModule models.GoSource, versionNumber string, extension string) string {
+	escaped, err := module.EscapeVersion(versionNumber)
+	if err != nil {
+		escaped = versionNumber
+	}
+
+	return GoModulePath(goModule, "@v/"+escaped+extension)
+}
+
+// GoModuleVersion is a version of a Go module, ordered by the Go semver rules
+type GoModuleVersion string
+
+// Compare returns -1, 0 or 1 when the version is lower, equal or greater
+// than the other version
+func (v GoModuleVersion) Compare(comparable Comparable) int {
+	return semver.Compare(string(v), string(comparable.(GoModuleVersion)))
+}
+
+// ExtractGo a version from the GOPROXY path of a module zip
+func ExtractGo(zipPath string, goModule models.GoSource) (Extraction, bool) {
+	prefix := GoModulePath(goModule, "@v/")
+	if !strings.HasPrefix(zipPath, prefix) || !strings.HasSuffix(zipPath, ".zip") {
+		return Extraction{}, false
+	}
+
+	versionNumber, err := module.UnescapeVersion(strings.TrimSuffix(strings.TrimPrefix(zipPath, prefix), ".zip"))
+	if err != nil || !semver.IsValid(versionNumber) || (versionNumber != semver.Canonical(versionNumber) && !strings.HasSuffix(versionNumber, "+incompatible")) {
+		return Extraction{}, false
+	}
+
+	if module.IsPseudoVersion(versionNumber) && !goModule.PseudoVersions {
+		return Extraction{}, false
+	}
+
+	// Only used for display, the ordering relies on the Go semver rules
+	ver, _ := version.NewVersionFromString(strings.TrimPrefix(versionNumber, "v"))
+
+	return Extraction{
+		Path:          zipPath,
+		Version:       ver,
+		VersionNumber: versionNumber,
+		Comparable:    GoModuleVersion(versionNumber),
+	}, true
+}
+
+// GetGoModuleInfo returns the .info of a module version, or of the latest
+// version for the @latest query
+func GetGoModuleInfo(client nexusresource.NexusClient, repositoryName string, goModule models.GoSource, versionNumber string) (models.GoModuleInfo, error) {
+	var info models.GoModuleInfo
+
+	name := GoModuleVersionPath(goModule, versionNumber, ".info")
+	if versionNumber == "latest" {
+		name = GoModulePath(goModule, "@latest")
+	}
+
+	content, err := client.GetFile(repositoryName, name)
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(content, &info)
+	return info, err
+}
+
+// getGoVersions returns the Extractions of the versions of a Go module listed
+// by @v/list, and of its @latest pseudo-version when enabled
+func getGoVersions(client nexusresource.NexusClient, source models.Source) (Extractions, error) {
+	l := utils.NewLogger(source.Debug)
+	l.LogSimpleMessage("In getGoVersions reading version list for '%s'", source.Go.Module)
+
+	content, err := client.GetFile(source.Repository, GoModulePath(source.Go, "@v/list"))
+	if err != nil {
+		return nil, fmt.Errorf("reading go module versions: %s", err)
+	}
+	versionNumbers := strings.Fields(string(content))
+
+	// Untagged commits are not part of the list, only the latest one is known
+	if source.Go.PseudoVersions {
+		info, err := GetGoModuleInfo(client, source.Repository, source.Go, "latest")
+		if err == nil && module.IsPseudoVersion(info.Version) && sliceIndex(versionNumbers, info.Version) < 0 {
+			versionNumbers = append(versionNumbers, info.Version)
+		}
+	}
+
+	var extractions = make(Extractions, 0, len(versionNumbers))
+	for _, versionNumber := range versionNumbers {
+		extraction, ok := ExtractGo(GoModuleVersionPath(source.Go, versionNumber, ".zip"), source.Go)
+
+		if ok {
+			extractions = append(extractions, extraction)
+		}
+	}
+
+	l.LogSimpleMessage("In getGoVersions extracted '%d' versions from the version list", len(extractions))
+
+	return extractions, nil
+}
@@ -0,0 +1,64 @@
+package versions_test
+
+import (
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("ExtractGo", func() {
+	var goModule models.GoSource
+
+	BeforeEach(func() {
+		goModule = models.GoSource{Module: "github.com/Contoso/billing"}
+	})
+
+	It("builds the case-encoded path of a module version", func() {
+		Ω(versions.GoModuleVersionPath(goModule, "v1.2.0", ".zip")).Should(Equal("github.com/!contoso/billing/@v/v1.2.0.zip"))
+	})
+
+	It("extracts the version of a module zip", func() {
+		result, ok := versions.ExtractGo("github.com/!contoso/billing/@v/v1.2.0.zip", goModule)
+		Ω(ok).Should(BeTrue())
+		Ω(result.VersionNumber).Should(Equal("v1.2.0"))
+	})
+
+	It("extracts pseudo-versions only when enabled", func() {
+		_, ok := versions.ExtractGo("github.com/!contoso/billing/@v/v1.2.1-0.20261001123456-abcdef123456.zip", goModule)
+		Ω(ok).Should(BeFalse())
+
+		goModule.PseudoVersions = true
+		_, ok = versions.ExtractGo("github.com/!contoso/billing/@v/v1.2.1-0.20261001123456-abcdef123456.zip", goModule)
+		Ω(ok).Should(BeTrue())
+	})
+
+	It("doesn't extract other files of the module", func() {
+		_, ok := versions.ExtractGo("github.com/!contoso/billing/@v/v1.2.0.mod", goModule)
+		Ω(ok).Should(BeFalse())
+
+		_, ok = versions.ExtractGo("github.com/!contoso/billing/@v/latest.zip", goModule)
+		Ω(ok).Should(BeFalse())
+	})
+
+	It("orders versions by the Go semver rules", func() {
+		goModule.PseudoVersions = true
+
+		var extractions versions.Extractions
+		for _, versionNumber := range []string{"v1.10.0", "v1.2.1-0.20261001123456-abcdef123456", "v2.0.0+incompatible", "v1.2.0", "v1.10.0-rc.1"} {
+			extraction, ok := versions.ExtractGo(versions.GoModuleVersionPath(goModule, versionNumber, ".zip"), goModule)
+			Ω(ok).Should(BeTrue())
+			extractions = append(extractions, extraction)
+		}
+		sort.Sort(extractions)
+
+		var versionNumbers []string
+		for _, extraction := range extractions {
+			versionNumbers = append(versionNumbers, extraction.VersionNumber)
+		}
+		Ω(versionNumbers).Should(Equal([]string{"v1.2.0", "v1.2.1-0.20261001123456-abcdef123456", "v1.10.0-rc.1", "v1.10.0", "v2.0.0+incompatible"}))
+	})
+})
@@ -0,0 +1,48 @@
+package versions
+
+import (
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+)
+
+// IsGroupPattern reports whether a group is a glob pattern
+func IsGroupPattern(group string) bool {
+	return strings.ContainsAny(group, "*?[{")
+}
+
+// SearchGroup returns the components of a group, or of the groups matched by
+// a glob pattern with doublestar semantics. The components of a pattern are
+// found with a single search under the leading segments without any glob, and
+// matched by their group
+func SearchGroup(client nexusresource.NexusClient, repositoryName string, group string) ([]models.RepositoryItem, error) {
+	if !IsGroupPattern(group) {
+		return client.SearchComponents(repositoryName, map[string]string{"group": group})
+	}
+
+	items, err := client.SearchComponents(repositoryName, map[string]string{"group": strings.TrimSuffix(groupBase(group), "/") + "*"})
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]models.RepositoryItem, 0, len(items))
+	for _, item := range items {
+		if ok, _ := doublestar.Match(group, item.Group); ok {
+			matched = append(matched, item)
+		}
+	}
+
+	return matched, nil
+}
+
+// groupBase returns the leading segments of a group pattern without any glob
+func groupBase(group string) string {
+	base, _ := doublestar.SplitPattern(group)
+	if base == "." {
+		return "/"
+	}
+
+	return base
+}
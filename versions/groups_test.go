@@ -0,0 +1,63 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/fakes"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("SearchGroup", func() {
+	var nexusclient *fakes.FakeNexusClient
+
+	search := func(group string) []string {
+		items, err := versions.SearchGroup(nexusclient, "repository-name", group)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var names []string
+		for _, item := range items {
+			names = append(names, item.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		nexusclient = &fakes.FakeNexusClient{}
+		nexusclient.SearchComponentsReturns([]models.RepositoryItem{
+			{Group: "/releases", Name: "releases/notes.txt"},
+			{Group: "/releases/1.0/linux", Name: "releases/1.0/linux/app.tgz"},
+			{Group: "/releases/1.0/windows", Name: "releases/1.0/windows/app.zip"},
+			{Group: "/releases/1.1/linux", Name: "releases/1.1/linux/app.tgz"},
+			{Group: "/releases/1.1/linux/debug", Name: "releases/1.1/linux/debug/app.tgz"},
+			{Group: "/releases-old", Name: "releases-old/app.tgz"},
+		}, nil)
+	})
+
+	It("searches a group which isn't a pattern as is", func() {
+		search("/releases/1.0/linux")
+
+		_, parameters := nexusclient.SearchComponentsArgsForCall(0)
+		Ω(parameters).Should(Equal(map[string]string{"group": "/releases/1.0/linux"}))
+	})
+
+	It("matches a single segment with *", func() {
+		Ω(search("/releases/*/linux")).Should(Equal([]string{"releases/1.0/linux/app.tgz", "releases/1.1/linux/app.tgz"}))
+
+		Ω(nexusclient.SearchComponentsCallCount()).Should(Equal(1))
+		repositoryName, parameters := nexusclient.SearchComponentsArgsForCall(0)
+		Ω(repositoryName).Should(Equal("repository-name"))
+		Ω(parameters).Should(Equal(map[string]string{"group": "/releases*"}))
+	})
+
+	It("matches the subgroups recursively with **", func() {
+		Ω(search("/releases/**")).Should(Equal([]string{
+			"releases/notes.txt",
+			"releases/1.0/linux/app.tgz",
+			"releases/1.0/windows/app.zip",
+			"releases/1.1/linux/app.tgz",
+			"releases/1.1/linux/debug/app.tgz",
+		}))
+	})
+})
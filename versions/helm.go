@@ -0,0 +1,116 @@
+package versions
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/cppforlife/go-semi-semantic/version"
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const helmIndexFile = "index.yaml"
+
+// HelmChartPath returns the repository path of a packaged chart version
+func HelmChartPath(helm models.HelmSource, versionNumber string) string {
+	return helm.Chart + "-" + versionNumber + ".tgz"
+}
+
+// ExtractHelm a version from the path of a packaged chart
+func ExtractHelm(chartPath string, helm models.HelmSource) (Extraction, bool) {
+	filename := path.Base(chartPath)
+	prefix := helm.Chart + "-"
+	if !strings.HasPrefix(filename, prefix) || !strings.HasSuffix(filename, ".tgz") {
+		return Extraction{}, false
+	}
+	match := strings.TrimSuffix(strings.TrimPrefix(filename, prefix), ".tgz")
+
+	// Helm orders the chart versions by semver precedence, leniently parsed
+	// like Helm does
+	semverVersion, err := semver.NewVersion(match)
+	if err != nil {
+		return Extraction{}, false
+	}
+
+	// Only used for display, the ordering relies on the semver version
+	ver, _ := version.NewVersionFromString(match)
+
+	return Extraction{
+		Path:          chartPath,
+		Version:       ver,
+		VersionNumber: match,
+		Comparable:    SemverVersion{semverVersion},
+	}, true
+}
+
+// GetHelmChartVersions returns the versions of the chart listed in the index.yaml
+func GetHelmChartVersions(client nexusresource.NexusClient, repositoryName string, helm models.HelmSource) ([]models.HelmChartVersion, error) {
+	content, err := client.GetFile(repositoryName, helmIndexFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var index models.HelmIndex
+	err = yaml.Unmarshal(content, &index)
+	if err != nil {
+		return nil, err
+	}
+
+	return index.Entries[helm.Chart], nil
+}
+
+// HelmChartVersionPath returns the repository path of a chart version from its urls
+func HelmChartVersionPath(chartVersion models.HelmChartVersion, repositoryName string, helm models.HelmSource) string {
+	for _, link := range chartVersion.URLs {
+		u, err := url.Parse(link)
+		if err != nil {
+			continue
+		}
+
+		if chartPath, ok := repositoryPath(u, repositoryName, ""); ok {
+			return chartPath
+		}
+	}
+
+	return HelmChartPath(helm, chartVersion.Version)
+}
+
+// getHelmVersions returns the Extractions of the chart versions listed in the
+// index.yaml, filtered on their appVersion when configured
+func getHelmVersions(client nexusresource.NexusClient, source models.Source) (Extractions, error) {
+	l := utils.NewLogger(source.Debug)
+	l.LogSimpleMessage("In getHelmVersions reading index for chart '%s'", source.Helm.Chart)
+
+	chartVersions, err := GetHelmChartVersions(client, source.Repository, source.Helm)
+	if err != nil {
+		return nil, fmt.Errorf("reading helm index: %s", err)
+	}
+
+	var extractions = make(Extractions, 0, len(chartVersions))
+	for _, chartVersion := range chartVersions {
+		if source.Helm.AppVersion != "" {
+			matched, err := Match([]string{chartVersion.AppVersion}, source.Helm.AppVersion)
+			if err != nil {
+				return nil, fmt.Errorf("matching app version: %s", err)
+			}
+			if len(matched) == 0 {
+				continue
+			}
+		}
+
+		extraction, ok := ExtractHelm(HelmChartVersionPath(chartVersion, source.Repository, source.Helm), source.Helm)
+
+		if ok {
+			extractions = append(extractions, extraction)
+		}
+	}
+
+	l.LogSimpleMessage("In getHelmVersions extracted '%d' versions from the index", len(extractions))
+
+	return extractions, nil
+}
@@ -0,0 +1,48 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("ExtractHelm", func() {
+	helm := models.HelmSource{Chart: "ingress-gateway"}
+
+	It("extracts the version of a packaged chart", func() {
+		result, ok := versions.ExtractHelm("ingress-gateway-1.2.0-rc.1.tgz", helm)
+		Ω(ok).Should(BeTrue())
+		Ω(result.VersionNumber).Should(Equal("1.2.0-rc.1"))
+	})
+
+	It("extracts the version of a chart stored in a sub-group", func() {
+		result, ok := versions.ExtractHelm("charts/ingress-gateway-1.2.0.tgz", helm)
+		Ω(ok).Should(BeTrue())
+		Ω(result.Path).Should(Equal("charts/ingress-gateway-1.2.0.tgz"))
+	})
+
+	It("orders the chart versions by semver precedence", func() {
+		versionNumbers := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0"}
+		for i := 1; i < len(versionNumbers); i++ {
+			lower, ok := versions.ExtractHelm("ingress-gateway-"+versionNumbers[i-1]+".tgz", helm)
+			Ω(ok).Should(BeTrue())
+			higher, ok := versions.ExtractHelm("ingress-gateway-"+versionNumbers[i]+".tgz", helm)
+			Ω(ok).Should(BeTrue())
+
+			Ω(lower.Compare(higher)).Should(Equal(-1), versionNumbers[i-1]+" < "+versionNumbers[i])
+		}
+	})
+
+	It("ignores the build metadata in the ordering", func() {
+		build1, _ := versions.ExtractHelm("ingress-gateway-1.0.0+build.1.tgz", helm)
+		build2, _ := versions.ExtractHelm("ingress-gateway-1.0.0+build.2.tgz", helm)
+		Ω(build1.Compare(build2)).Should(Equal(0))
+	})
+
+	It("doesn't extract other charts", func() {
+		_, ok := versions.ExtractHelm("ingress-1.2.0.tgz", helm)
+		Ω(ok).Should(BeFalse())
+	})
+})
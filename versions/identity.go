@@ -0,0 +1,50 @@
+package versions
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+)
+
+// ResolveVersionPath returns the current path of a version number of the
+// provided raw Source, the path of the latest version with that number when it
+// is found under several paths. All the versions of the repository are looked
+// up, as a version check emitted may be filtered out by now, e.g. by max_age
+func ResolveVersionPath(client nexusresource.NexusClient, source models.Source, versionNumber string) (string, error) {
+	extractions, _, err := getRawSourceVersions(client, source)
+	if err != nil {
+		return "", err
+	}
+	sort.Sort(extractions)
+
+	for i := len(extractions) - 1; i >= 0; i-- {
+		if extractions[i].VersionNumber == versionNumber {
+			return extractions[i].Path, nil
+		}
+	}
+
+	return "", fmt.Errorf("version %s was not found in the repository", versionNumber)
+}
+
+// UniqueVersionNumbers returns the Extractions without the lower ones of a
+// version number found under several paths
+func UniqueVersionNumbers(extractions Extractions) Extractions {
+	seen := map[string]bool{}
+	unique := make(Extractions, 0, len(extractions))
+	for i := len(extractions) - 1; i >= 0; i-- {
+		if seen[extractions[i].VersionNumber] {
+			continue
+		}
+
+		seen[extractions[i].VersionNumber] = true
+		unique = append(unique, extractions[i])
+	}
+
+	for i, j := 0, len(unique)-1; i < j; i, j = i+1, j-1 {
+		unique[i], unique[j] = unique[j], unique[i]
+	}
+
+	return unique
+}
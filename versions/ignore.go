@@ -0,0 +1,86 @@
+package versions
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+// FilterIgnored returns the Extractions whose path isn't matched by any of the
+// ignore_regexps or ignore_globs of the provided Source
+func FilterIgnored(extractions Extractions, source models.Source, l *utils.StandardLogger) (Extractions, error) {
+	ignore, err := newIgnoreMatcher(source)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make(Extractions, 0, len(extractions))
+	for _, extraction := range extractions {
+		if pattern, ok := ignore.match(extraction.Path); ok {
+			l.LogSimpleMessage("In FilterIgnored excluding '%s' matched by '%s'", extraction.Path, pattern)
+			continue
+		}
+
+		filtered = append(filtered, extraction)
+	}
+
+	return filtered, nil
+}
+
+// filterIgnoredSkipped returns the skipped paths which aren't ignored, so an
+// ignored path is neither reported nor fails the check in strict mode
+func filterIgnoredSkipped(skipped []skippedPath, source models.Source) ([]skippedPath, error) {
+	ignore, err := newIgnoreMatcher(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []skippedPath
+	for _, s := range skipped {
+		if _, ok := ignore.match(s.path); !ok {
+			filtered = append(filtered, s)
+		}
+	}
+
+	return filtered, nil
+}
+
+// ignoreMatcher matches paths against the anchored ignore_regexps and the
+// ignore_globs of a Source
+type ignoreMatcher struct {
+	regexps []*regexp.Regexp
+	globs   []string
+}
+
+func newIgnoreMatcher(source models.Source) (ignoreMatcher, error) {
+	ignore := ignoreMatcher{globs: source.IgnoreGlobs}
+	for _, pattern := range source.IgnoreRegexps {
+		compiled, err := regexp.Compile("^" + pattern + "$")
+		if err != nil {
+			return ignore, fmt.Errorf("parsing ignore_regexps: %s", err)
+		}
+		ignore.regexps = append(ignore.regexps, compiled)
+	}
+
+	return ignore, nil
+}
+
+// match returns the ignore regexp or glob matching a path
+func (ignore ignoreMatcher) match(path string) (string, bool) {
+	for _, compiled := range ignore.regexps {
+		if compiled.MatchString(path) {
+			return compiled.String(), true
+		}
+	}
+
+	for _, glob := range ignore.globs {
+		if ok, _ := doublestar.Match(glob, path); ok {
+			return glob, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,154 @@
+package versions
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/cppforlife/go-semi-semantic/version"
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+const mavenMetadataFile = "maven-metadata.xml"
+
+// matches the timestamp and build number of a unique snapshot version
+var mavenSnapshotPattern = regexp.MustCompile(`^(\d{8})\.(\d{6})-(\d+)$`)
+
+// MavenArtifactPath returns the repository path of the configured Maven artifact
+func MavenArtifactPath(maven models.MavenSource) string {
+	return path.Join(strings.ReplaceAll(maven.GroupID, ".", "/"), maven.ArtifactID)
+}
+
+// MavenFilePath returns the repository path of an artifact file for a base and resolved version
+func MavenFilePath(maven models.MavenSource, baseVersion string, value string) string {
+	filename := maven.ArtifactID + "-" + value
+	if maven.Classifier != "" {
+		filename += "-" + maven.Classifier
+	}
+	filename += "." + maven.FileExtension()
+
+	return path.Join(MavenArtifactPath(maven), baseVersion, filename)
+}
+
+// MavenGAV returns the coordinates of an artifact as groupId:artifactId:version
+func MavenGAV(maven models.MavenSource, value string) string {
+	return strings.Join([]string{maven.GroupID, maven.ArtifactID, value}, ":")
+}
+
+// ExtractMaven a version from the path of a Maven artifact file
+func ExtractMaven(filePath string, maven models.MavenSource) (Extraction, bool) {
+	baseVersion := path.Base(path.Dir(filePath))
+	if path.Dir(path.Dir(filePath)) != MavenArtifactPath(maven) {
+		return Extraction{}, false
+	}
+
+	suffix := "." + maven.FileExtension()
+	if maven.Classifier != "" {
+		suffix = "-" + maven.Classifier + suffix
+	}
+
+	filename := path.Base(filePath)
+	prefix := maven.ArtifactID + "-"
+	if !strings.HasPrefix(filename, prefix) || !strings.HasSuffix(filename, suffix) {
+		return Extraction{}, false
+	}
+	value := strings.TrimSuffix(strings.TrimPrefix(filename, prefix), suffix)
+
+	sortable := value
+	if strings.HasSuffix(baseVersion, "-SNAPSHOT") {
+		release := strings.TrimSuffix(baseVersion, "SNAPSHOT")
+		if !strings.HasPrefix(value, release) {
+			return Extraction{}, false
+		}
+
+		// Order unique snapshots by their timestamp and build number
+		matches := mavenSnapshotPattern.FindStringSubmatch(strings.TrimPrefix(value, release))
+		if matches == nil {
+			return Extraction{}, false
+		}
+		sortable = strings.Join(matches[1:], ".")
+	} else if value != baseVersion {
+		return Extraction{}, false
+	}
+
+	ver, err := version.NewVersionFromString(sortable)
+	if err != nil {
+		return Extraction{}, false
+	}
+
+	return Extraction{
+		Path:          filePath,
+		Version:       ver,
+		VersionNumber: value,
+	}, true
+}
+
+func getMavenMetadata(client nexusresource.NexusClient, repositoryName string, metadataPath string) (models.MavenMetadata, error) {
+	var metadata models.MavenMetadata
+
+	content, err := client.GetFile(repositoryName, metadataPath)
+	if err != nil {
+		return metadata, err
+	}
+
+	err = xml.Unmarshal(content, &metadata)
+	return metadata, err
+}
+
+// ListMavenVersions returns the unfiltered Extractions of a Maven artifact read
+// from its maven-metadata.xml, either the unique snapshot builds of the
+// configured SNAPSHOT version or the releases
+func ListMavenVersions(client nexusresource.NexusClient, source models.Source) (Extractions, error) {
+	l := utils.NewLogger(source.Debug)
+	maven := source.Maven
+
+	var paths []string
+	if maven.IsSnapshot() {
+		l.LogSimpleMessage("In ListMavenVersions reading snapshot builds for '%s'", maven.Version)
+		metadata, err := getMavenMetadata(client, source.Repository, path.Join(MavenArtifactPath(maven), maven.Version, mavenMetadataFile))
+		if err != nil {
+			return nil, fmt.Errorf("reading maven metadata: %s", err)
+		}
+
+		for _, snapshot := range metadata.Versioning.SnapshotVersions {
+			if snapshot.Extension == maven.FileExtension() && snapshot.Classifier == maven.Classifier {
+				paths = append(paths, MavenFilePath(maven, maven.Version, snapshot.Value))
+			}
+		}
+
+		// Metadata written by Maven 2 only records the latest build
+		if len(metadata.Versioning.SnapshotVersions) == 0 && metadata.Versioning.Snapshot.Timestamp != "" {
+			value := fmt.Sprintf("%s%s-%d", strings.TrimSuffix(maven.Version, "SNAPSHOT"), metadata.Versioning.Snapshot.Timestamp, metadata.Versioning.Snapshot.BuildNumber)
+			paths = append(paths, MavenFilePath(maven, maven.Version, value))
+		}
+	} else {
+		l.LogSimpleMessage("In ListMavenVersions reading releases for '%s'", MavenArtifactPath(maven))
+		metadata, err := getMavenMetadata(client, source.Repository, path.Join(MavenArtifactPath(maven), mavenMetadataFile))
+		if err != nil {
+			return nil, fmt.Errorf("reading maven metadata: %s", err)
+		}
+
+		for _, release := range metadata.Versioning.Versions {
+			if !strings.HasSuffix(release, "-SNAPSHOT") {
+				paths = append(paths, MavenFilePath(maven, release, release))
+			}
+		}
+	}
+
+	var extractions = make(Extractions, 0, len(paths))
+	for _, filePath := range paths {
+		extraction, ok := ExtractMaven(filePath, maven)
+
+		if ok {
+			extractions = append(extractions, extraction)
+		}
+	}
+
+	l.LogSimpleMessage("In ListMavenVersions extracted '%d' versions from the metadata", len(extractions))
+
+	return extractions, nil
+}
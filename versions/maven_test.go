@@ -0,0 +1,63 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("ExtractMaven", func() {
+	var maven models.MavenSource
+
+	BeforeEach(func() {
+		maven = models.MavenSource{
+			GroupID:    "com.example",
+			ArtifactID: "app",
+			Version:    "1.2.0-SNAPSHOT",
+		}
+	})
+
+	It("builds the path of an artifact file", func() {
+		Ω(versions.MavenFilePath(maven, "1.2.0-SNAPSHOT", "1.2.0-20261001.123456-7")).Should(Equal("com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261001.123456-7.jar"))
+
+		maven.Classifier = "sources"
+		maven.Extension = "zip"
+		Ω(versions.MavenFilePath(maven, "1.2.0", "1.2.0")).Should(Equal("com/example/app/1.2.0/app-1.2.0-sources.zip"))
+	})
+
+	It("extracts a timestamped snapshot build", func() {
+		result, ok := versions.ExtractMaven("com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261001.123456-7.jar", maven)
+		Ω(ok).Should(BeTrue())
+
+		Ω(result.Path).Should(Equal("com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261001.123456-7.jar"))
+		Ω(result.VersionNumber).Should(Equal("1.2.0-20261001.123456-7"))
+	})
+
+	It("orders snapshot builds by timestamp and build number", func() {
+		older, ok := versions.ExtractMaven("com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261001.123456-9.jar", maven)
+		Ω(ok).Should(BeTrue())
+		newer, ok := versions.ExtractMaven("com/example/app/1.2.0-SNAPSHOT/app-1.2.0-20261001.130000-10.jar", maven)
+		Ω(ok).Should(BeTrue())
+
+		Ω(older.Version.IsLt(newer.Version)).Should(BeTrue())
+	})
+
+	It("extracts a release", func() {
+		result, ok := versions.ExtractMaven("com/example/app/1.2.0/app-1.2.0.jar", maven)
+		Ω(ok).Should(BeTrue())
+		Ω(result.VersionNumber).Should(Equal("1.2.0"))
+	})
+
+	It("doesn't extract files of other artifacts or classifiers", func() {
+		_, ok := versions.ExtractMaven("com/example/other/1.2.0/other-1.2.0.jar", maven)
+		Ω(ok).Should(BeFalse())
+
+		_, ok = versions.ExtractMaven("com/example/app/1.2.0/app-1.2.0-sources.jar", maven)
+		Ω(ok).Should(BeFalse())
+
+		_, ok = versions.ExtractMaven("com/example/app/1.2.0-SNAPSHOT/app-1.2.0-SNAPSHOT.jar", maven)
+		Ω(ok).Should(BeFalse())
+	})
+})
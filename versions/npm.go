@@ -0,0 +1,92 @@
+package versions
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/cppforlife/go-semi-semantic/version"
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+// NpmTarballPath returns the repository path of the tarball of a package version
+func NpmTarballPath(npm models.NpmSource, versionNumber string) string {
+	return path.Join(npm.Package, "-", path.Base(npm.Package)+"-"+versionNumber+".tgz")
+}
+
+// ExtractNpm a version from the path of an npm package tarball
+func ExtractNpm(tarballPath string, npm models.NpmSource) (Extraction, bool) {
+	if path.Dir(tarballPath) != path.Join(npm.Package, "-") {
+		return Extraction{}, false
+	}
+
+	filename := path.Base(tarballPath)
+	prefix := path.Base(npm.Package) + "-"
+	if !strings.HasPrefix(filename, prefix) || !strings.HasSuffix(filename, ".tgz") {
+		return Extraction{}, false
+	}
+	match := strings.TrimSuffix(strings.TrimPrefix(filename, prefix), ".tgz")
+
+	ver, err := version.NewVersionFromString(match)
+	if err != nil {
+		return Extraction{}, false
+	}
+
+	return Extraction{
+		Path:          tarballPath,
+		Version:       ver,
+		VersionNumber: match,
+	}, true
+}
+
+// GetNpmPackage returns the package document of an npm package
+func GetNpmPackage(client nexusresource.NexusClient, repositoryName string, npm models.NpmSource) (models.NpmPackage, error) {
+	var npmPackage models.NpmPackage
+
+	content, err := client.GetFile(repositoryName, npm.Package)
+	if err != nil {
+		return npmPackage, err
+	}
+
+	err = json.Unmarshal(content, &npmPackage)
+	return npmPackage, err
+}
+
+// getNpmVersions returns the Extractions of the published versions of an npm
+// package, or only the version of the configured dist-tag
+func getNpmVersions(client nexusresource.NexusClient, source models.Source) (Extractions, error) {
+	l := utils.NewLogger(source.Debug)
+	l.LogSimpleMessage("In getNpmVersions reading package '%s'", source.Npm.Package)
+
+	npmPackage, err := GetNpmPackage(client, source.Repository, source.Npm)
+	if err != nil {
+		return nil, fmt.Errorf("reading npm package: %s", err)
+	}
+
+	var versionNumbers []string
+	if source.Npm.DistTag != "" {
+		if tagged, ok := npmPackage.DistTags[source.Npm.DistTag]; ok {
+			versionNumbers = append(versionNumbers, tagged)
+		}
+	} else {
+		for versionNumber := range npmPackage.Versions {
+			versionNumbers = append(versionNumbers, versionNumber)
+		}
+	}
+
+	var extractions = make(Extractions, 0, len(versionNumbers))
+	for _, versionNumber := range versionNumbers {
+		extraction, ok := ExtractNpm(NpmTarballPath(source.Npm, versionNumber), source.Npm)
+
+		if ok {
+			extractions = append(extractions, extraction)
+		}
+	}
+
+	l.LogSimpleMessage("In getNpmVersions extracted '%d' versions from the package", len(extractions))
+
+	return extractions, nil
+}
@@ -0,0 +1,33 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("ExtractNpm", func() {
+	It("builds the tarball path of a scoped package", func() {
+		npm := models.NpmSource{Package: "@frontend/widgets"}
+		Ω(versions.NpmTarballPath(npm, "2.1.0")).Should(Equal("@frontend/widgets/-/widgets-2.1.0.tgz"))
+	})
+
+	It("extracts the version of a package tarball", func() {
+		result, ok := versions.ExtractNpm("left-pad/-/left-pad-1.3.0.tgz", models.NpmSource{Package: "left-pad"})
+		Ω(ok).Should(BeTrue())
+		Ω(result.VersionNumber).Should(Equal("1.3.0"))
+	})
+
+	It("extracts the version of a scoped package tarball", func() {
+		result, ok := versions.ExtractNpm("@frontend/widgets/-/widgets-2.1.0-beta.1.tgz", models.NpmSource{Package: "@frontend/widgets"})
+		Ω(ok).Should(BeTrue())
+		Ω(result.VersionNumber).Should(Equal("2.1.0-beta.1"))
+	})
+
+	It("doesn't extract tarballs of other packages", func() {
+		_, ok := versions.ExtractNpm("@other/widgets/-/widgets-2.1.0.tgz", models.NpmSource{Package: "@frontend/widgets"})
+		Ω(ok).Should(BeFalse())
+	})
+})
@@ -0,0 +1,150 @@
+package versions
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cppforlife/go-semi-semantic/version"
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+// NuGet versions have up to four numeric parts, and SemVer 2 pre-release and metadata
+var nugetVersionPattern = regexp.MustCompile(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+
+// NuGetVersion struct is a version parsed according to the NuGet SemVer 2 rules
+type NuGetVersion struct {
+	Release    [4]int
+	PreRelease []string
+}
+
+// NewNuGetVersion parses a NuGet version, the build metadata is ignored
+func NewNuGetVersion(value string) (NuGetVersion, error) {
+	matches := nugetVersionPattern.FindStringSubmatch(value)
+	if matches == nil {
+		return NuGetVersion{}, fmt.Errorf("'%s' is not a valid NuGet version", value)
+	}
+
+	var ver NuGetVersion
+	for i := range ver.Release {
+		ver.Release[i], _ = strconv.Atoi(matches[i+1])
+	}
+
+	if matches[5] != "" {
+		ver.PreRelease = strings.Split(matches[5], ".")
+	}
+
+	return ver, nil
+}
+
+// Compare returns -1, 0 or 1 when the version is lower, equal or greater
+// than the other version, pre-release labels are compared case insensitively
+func (v NuGetVersion) Compare(comparable Comparable) int {
+	other := comparable.(NuGetVersion)
+	if c := compareInts(v.Release[:], other.Release[:]); c != 0 {
+		return c
+	}
+
+	// A release sorts after its pre-releases
+	switch {
+	case len(v.PreRelease) == 0 && len(other.PreRelease) == 0:
+		return 0
+	case len(v.PreRelease) == 0:
+		return 1
+	case len(other.PreRelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.PreRelease) && i < len(other.PreRelease); i++ {
+		x, xErr := strconv.Atoi(v.PreRelease[i])
+		y, yErr := strconv.Atoi(other.PreRelease[i])
+
+		switch {
+		case xErr == nil && yErr == nil:
+			if x != y {
+				return compareInts([]int{x}, []int{y})
+			}
+		case xErr == nil:
+			return -1
+		case yErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(strings.ToLower(v.PreRelease[i]), strings.ToLower(other.PreRelease[i])); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareInts([]int{len(v.PreRelease)}, []int{len(other.PreRelease)})
+}
+
+// NuGetPackagePath returns the flat container path of the .nupkg of a package version
+func NuGetPackagePath(nuget models.NuGetSource, versionNumber string) string {
+	id := strings.ToLower(nuget.Package)
+	versionNumber = strings.ToLower(versionNumber)
+	return "v3-flatcontainer/" + id + "/" + versionNumber + "/" + id + "." + versionNumber + ".nupkg"
+}
+
+// ExtractNuGet a version from the flat container path of a .nupkg
+func ExtractNuGet(packagePath string, nuget models.NuGetSource) (Extraction, bool) {
+	versionNumber := path.Base(path.Dir(packagePath))
+	if packagePath != NuGetPackagePath(nuget, versionNumber) {
+		return Extraction{}, false
+	}
+
+	nugetVersion, err := NewNuGetVersion(versionNumber)
+	if err != nil {
+		return Extraction{}, false
+	}
+
+	// Only used for display, the ordering relies on the NuGet version
+	ver, _ := version.NewVersionFromString(versionNumber)
+
+	return Extraction{
+		Path:          packagePath,
+		Version:       ver,
+		VersionNumber: versionNumber,
+		Comparable:    nugetVersion,
+	}, true
+}
+
+// GetNuGetVersions returns the versions of a package listed in the flat container
+func GetNuGetVersions(client nexusresource.NexusClient, repositoryName string, nuget models.NuGetSource) ([]string, error) {
+	content, err := client.GetFile(repositoryName, "v3-flatcontainer/"+strings.ToLower(nuget.Package)+"/index.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var index models.NuGetVersionIndex
+	err = json.Unmarshal(content, &index)
+	return index.Versions, err
+}
+
+// getNuGetVersions returns the Extractions of the versions of a NuGet package
+func getNuGetVersions(client nexusresource.NexusClient, source models.Source) (Extractions, error) {
+	l := utils.NewLogger(source.Debug)
+	l.LogSimpleMessage("In getNuGetVersions reading flat container for '%s'", source.NuGet.Package)
+
+	versionNumbers, err := GetNuGetVersions(client, source.Repository, source.NuGet)
+	if err != nil {
+		return nil, fmt.Errorf("reading nuget versions: %s", err)
+	}
+
+	var extractions = make(Extractions, 0, len(versionNumbers))
+	for _, versionNumber := range versionNumbers {
+		extraction, ok := ExtractNuGet(NuGetPackagePath(source.NuGet, versionNumber), source.NuGet)
+
+		if ok {
+			extractions = append(extractions, extraction)
+		}
+	}
+
+	l.LogSimpleMessage("In getNuGetVersions extracted '%d' versions from the flat container", len(extractions))
+
+	return extractions, nil
+}
@@ -0,0 +1,62 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("NuGetVersion", func() {
+	compare := func(a string, b string) int {
+		x, err := versions.NewNuGetVersion(a)
+		Ω(err).ShouldNot(HaveOccurred())
+		y, err := versions.NewNuGetVersion(b)
+		Ω(err).ShouldNot(HaveOccurred())
+		return x.Compare(y)
+	}
+
+	It("orders the numeric parts, including the revision", func() {
+		Ω(compare("1.2", "1.2.0.0")).Should(Equal(0))
+		Ω(compare("1.2.3", "1.2.3.1")).Should(Equal(-1))
+		Ω(compare("1.10.0", "1.9.0")).Should(Equal(1))
+	})
+
+	It("orders pre-releases before their release", func() {
+		Ω(compare("1.0.0-beta", "1.0.0")).Should(Equal(-1))
+		Ω(compare("1.0.0-alpha.2", "1.0.0-alpha.10")).Should(Equal(-1))
+		Ω(compare("1.0.0-alpha", "1.0.0-alpha.1")).Should(Equal(-1))
+		Ω(compare("1.0.0-1", "1.0.0-alpha")).Should(Equal(-1))
+	})
+
+	It("compares pre-release labels case insensitively and ignores metadata", func() {
+		Ω(compare("1.0.0-Beta", "1.0.0-beta")).Should(Equal(0))
+		Ω(compare("1.0.0+build.1", "1.0.0+build.2")).Should(Equal(0))
+	})
+
+	It("rejects invalid versions", func() {
+		_, err := versions.NewNuGetVersion("1.0.0.0.0")
+		Ω(err).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("ExtractNuGet", func() {
+	nuget := models.NuGetSource{Package: "Contoso.Billing"}
+
+	It("builds the flat container path with lowercase id and version", func() {
+		Ω(versions.NuGetPackagePath(nuget, "2.1.0-RC.1")).Should(Equal("v3-flatcontainer/contoso.billing/2.1.0-rc.1/contoso.billing.2.1.0-rc.1.nupkg"))
+	})
+
+	It("extracts the version of a package", func() {
+		result, ok := versions.ExtractNuGet("v3-flatcontainer/contoso.billing/2.1.0/contoso.billing.2.1.0.nupkg", nuget)
+		Ω(ok).Should(BeTrue())
+		Ω(result.VersionNumber).Should(Equal("2.1.0"))
+		Ω(result.Comparable).Should(BeAssignableToTypeOf(versions.NuGetVersion{}))
+	})
+
+	It("doesn't extract other packages", func() {
+		_, ok := versions.ExtractNuGet("v3-flatcontainer/contoso.api/2.1.0/contoso.api.2.1.0.nupkg", nuget)
+		Ω(ok).Should(BeFalse())
+	})
+})
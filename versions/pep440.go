@@ -0,0 +1,184 @@
+package versions
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Canonical PEP 440 pattern, see https://peps.python.org/pep-0440/#appendix-b-parsing-version-strings-with-regular-expressions
+var pep440Pattern = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?\s*$`)
+
+// Pep440Version struct is a version parsed according to PEP 440
+type Pep440Version struct {
+	Epoch   int
+	Release []int
+	// pre-release label, one of a, b or rc, empty when not a pre-release
+	PreLabel  string
+	PreNumber int
+	// post and dev release numbers, -1 when absent
+	Post  int
+	Dev   int
+	Local []string
+}
+
+// NewPep440Version parses a version string according to PEP 440
+func NewPep440Version(value string) (Pep440Version, error) {
+	matches := pep440Pattern.FindStringSubmatch(value)
+	if matches == nil {
+		return Pep440Version{}, fmt.Errorf("'%s' is not a valid PEP 440 version", value)
+	}
+
+	group := func(name string) string {
+		return matches[pep440Pattern.SubexpIndex(name)]
+	}
+	number := func(name string) int {
+		n, _ := strconv.Atoi(group(name))
+		return n
+	}
+
+	ver := Pep440Version{
+		Epoch: number("epoch"),
+		Post:  -1,
+		Dev:   -1,
+	}
+
+	for _, segment := range strings.Split(group("release"), ".") {
+		n, _ := strconv.Atoi(segment)
+		ver.Release = append(ver.Release, n)
+	}
+
+	switch strings.ToLower(group("pre_l")) {
+	case "":
+	case "alpha", "a":
+		ver.PreLabel = "a"
+	case "beta", "b":
+		ver.PreLabel = "b"
+	default:
+		ver.PreLabel = "rc"
+	}
+	ver.PreNumber = number("pre_n")
+
+	if group("post_n1") != "" {
+		ver.Post = number("post_n1")
+	} else if group("post_l") != "" {
+		ver.Post = number("post_n2")
+	}
+
+	if group("dev_l") != "" {
+		ver.Dev = number("dev_n")
+	}
+
+	if local := group("local"); local != "" {
+		ver.Local = strings.FieldsFunc(strings.ToLower(local), func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+
+	return ver, nil
+}
+
+// Compare returns -1, 0 or 1 when the version is lower, equal or greater
+// than the other version, following the PEP 440 ordering
+func (v Pep440Version) Compare(comparable Comparable) int {
+	other := comparable.(Pep440Version)
+	if c := compareInts([]int{v.Epoch}, []int{other.Epoch}); c != 0 {
+		return c
+	}
+
+	if c := compareInts(v.Release, other.Release); c != 0 {
+		return c
+	}
+
+	if c := compareInts(v.preKey(), other.preKey()); c != 0 {
+		return c
+	}
+
+	// A missing post release sorts before any post release
+	if c := compareInts([]int{v.Post}, []int{other.Post}); c != 0 {
+		return c
+	}
+
+	// A missing dev release sorts after any dev release
+	if c := compareInts([]int{devKey(v.Dev)}, []int{devKey(other.Dev)}); c != 0 {
+		return c
+	}
+
+	return compareLocal(v.Local, other.Local)
+}
+
+// preKey orders dev releases of a final release before its pre-releases, and
+// final releases after them
+func (v Pep440Version) preKey() []int {
+	switch {
+	case v.PreLabel == "" && v.Post == -1 && v.Dev != -1:
+		return []int{math.MinInt32}
+	case v.PreLabel == "":
+		return []int{math.MaxInt32}
+	}
+
+	return []int{strings.Index("a b rc", v.PreLabel), v.PreNumber}
+}
+
+func devKey(dev int) int {
+	if dev == -1 {
+		return math.MaxInt32
+	}
+
+	return dev
+}
+
+// compareInts compares two sequences, ignoring trailing zeros
+func compareInts(a []int, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// compareLocal compares local version labels, numeric segments sort after
+// alphanumeric ones and a longer label sorts after its prefix
+func compareLocal(a []string, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, xErr := strconv.Atoi(a[i])
+		y, yErr := strconv.Atoi(b[i])
+
+		switch {
+		case xErr == nil && yErr == nil:
+			if x != y {
+				return compareInts([]int{x}, []int{y})
+			}
+		case xErr == nil:
+			return 1
+		case yErr == nil:
+			return -1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareInts([]int{len(a)}, []int{len(b)})
+}
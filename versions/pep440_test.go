@@ -0,0 +1,57 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("Pep440Version", func() {
+	parse := func(value string) versions.Pep440Version {
+		ver, err := versions.NewPep440Version(value)
+		Ω(err).ShouldNot(HaveOccurred())
+		return ver
+	}
+
+	It("orders versions as per PEP 440", func() {
+		ordered := []string{
+			"1.0.dev456",
+			"1.0a1",
+			"1.0a2.dev456",
+			"1.0a12.dev456",
+			"1.0a12",
+			"1.0b1.dev456",
+			"1.0b2",
+			"1.0b2.post345.dev456",
+			"1.0b2.post345",
+			"1.0rc1.dev456",
+			"1.0rc1",
+			"1.0",
+			"1.0+abc.5",
+			"1.0+abc.7",
+			"1.0+5",
+			"1.0.post456.dev34",
+			"1.0.post456",
+			"1.1.dev1",
+			"1!0.1",
+		}
+
+		for i := 0; i < len(ordered)-1; i++ {
+			Ω(parse(ordered[i]).Compare(parse(ordered[i+1]))).Should(Equal(-1), ordered[i]+" < "+ordered[i+1])
+			Ω(parse(ordered[i+1]).Compare(parse(ordered[i]))).Should(Equal(1), ordered[i+1]+" > "+ordered[i])
+		}
+	})
+
+	It("normalizes alternative spellings", func() {
+		Ω(parse("1.0-alpha.1").Compare(parse("1.0a1"))).Should(Equal(0))
+		Ω(parse("1.0c1").Compare(parse("1.0rc1"))).Should(Equal(0))
+		Ω(parse("1.0-1").Compare(parse("1.0.post1"))).Should(Equal(0))
+		Ω(parse("v1.0.0").Compare(parse("1.0"))).Should(Equal(0))
+	})
+
+	It("errors on invalid versions", func() {
+		_, err := versions.NewPep440Version("latest")
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,222 @@
+package versions
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/cppforlife/go-semi-semantic/version"
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+// matches the anchors of a simple index page
+var pypiAnchorPattern = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+
+// matches the runs of separators which are equivalent in a package name
+var pypiSeparatorPattern = regexp.MustCompile(`[-_.]+`)
+
+var pypiSdistExtensions = []string{".tar.gz", ".tar.bz2", ".zip"}
+
+// PypiFile struct is a distribution file listed in the simple index
+type PypiFile struct {
+	// path to the file in the repository
+	Path string
+
+	Filename string
+
+	// the sha256 from the #sha256= fragment of the link
+	Sha256 string
+}
+
+// PypiDistribution struct is the information encoded in a distribution filename
+type PypiDistribution struct {
+	Name          string
+	VersionNumber string
+	Wheel         bool
+	PythonTags    []string
+	Platforms     []string
+}
+
+// NormalizePypiName normalizes a package name as per PEP 503
+func NormalizePypiName(name string) string {
+	return strings.ToLower(pypiSeparatorPattern.ReplaceAllString(name, "-"))
+}
+
+// PypiIndexPath returns the repository path of the simple index page of a package
+func PypiIndexPath(pypi models.PypiSource) string {
+	return "simple/" + NormalizePypiName(pypi.Package) + "/"
+}
+
+// ParsePypiFilename parses a wheel or sdist filename
+func ParsePypiFilename(filename string) (PypiDistribution, bool) {
+	if strings.HasSuffix(filename, ".whl") {
+		// {name}-{version}(-{build})?-{python}-{abi}-{platform}.whl
+		parts := strings.Split(strings.TrimSuffix(filename, ".whl"), "-")
+		if len(parts) != 5 && len(parts) != 6 {
+			return PypiDistribution{}, false
+		}
+
+		return PypiDistribution{
+			Name:          parts[0],
+			VersionNumber: parts[1],
+			Wheel:         true,
+			PythonTags:    strings.Split(parts[len(parts)-3], "."),
+			Platforms:     strings.Split(parts[len(parts)-1], "."),
+		}, true
+	}
+
+	for _, extension := range pypiSdistExtensions {
+		if strings.HasSuffix(filename, extension) {
+			base := strings.TrimSuffix(filename, extension)
+			index := strings.LastIndex(base, "-")
+			if index <= 0 {
+				return PypiDistribution{}, false
+			}
+
+			return PypiDistribution{
+				Name:          base[:index],
+				VersionNumber: base[index+1:],
+			}, true
+		}
+	}
+
+	return PypiDistribution{}, false
+}
+
+// ParsePypiIndex returns the distribution files linked from a simple index page
+func ParsePypiIndex(content string, repositoryName string, indexPath string) []PypiFile {
+	var files []PypiFile
+
+	for _, anchor := range pypiAnchorPattern.FindAllStringSubmatch(content, -1) {
+		href, err := url.Parse(html.UnescapeString(anchor[1]))
+		if err != nil {
+			continue
+		}
+
+		filePath, ok := repositoryPath(href, repositoryName, indexPath)
+		if !ok {
+			continue
+		}
+
+		file := PypiFile{
+			Path:     filePath,
+			Filename: path.Base(filePath),
+		}
+		if strings.HasPrefix(href.Fragment, "sha256=") {
+			file.Sha256 = strings.TrimPrefix(href.Fragment, "sha256=")
+		}
+
+		files = append(files, file)
+	}
+
+	return files
+}
+
+// GetPypiFiles returns the distribution files of a package from the simple index
+func GetPypiFiles(client nexusresource.NexusClient, repositoryName string, pypi models.PypiSource) ([]PypiFile, error) {
+	content, err := client.GetFile(repositoryName, PypiIndexPath(pypi))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePypiIndex(string(content), repositoryName, PypiIndexPath(pypi)), nil
+}
+
+// ExtractPypi a version from the path of a distribution file
+func ExtractPypi(filePath string, pypi models.PypiSource) (Extraction, bool) {
+	distribution, ok := ParsePypiFilename(path.Base(filePath))
+	if !ok || NormalizePypiName(distribution.Name) != NormalizePypiName(pypi.Package) {
+		return Extraction{}, false
+	}
+
+	pep440, err := NewPep440Version(distribution.VersionNumber)
+	if err != nil {
+		return Extraction{}, false
+	}
+
+	// Only used for display, the ordering relies on the PEP 440 version
+	ver, _ := version.NewVersionFromString(distribution.VersionNumber)
+
+	return Extraction{
+		Path:          filePath,
+		Version:       ver,
+		VersionNumber: distribution.VersionNumber,
+		Comparable:    pep440,
+	}, true
+}
+
+// pypiFileScore ranks how well a distribution matches the configured python
+// tag, platform and package type, 0 when it doesn't match
+func pypiFileScore(distribution PypiDistribution, pypi models.PypiSource) int {
+	if !distribution.Wheel {
+		if pypi.PackageType == "wheel" {
+			return 0
+		}
+		return 1
+	}
+
+	if pypi.PackageType == "sdist" {
+		return 0
+	}
+
+	if pypi.PythonTag != "" && !containsString(distribution.PythonTags, pypi.PythonTag) {
+		return 0
+	}
+
+	switch {
+	case pypi.Platform == "":
+		return 2
+	case containsString(distribution.Platforms, pypi.Platform):
+		return 3
+	case containsString(distribution.Platforms, "any"):
+		return 2
+	}
+
+	return 0
+}
+
+func containsString(haystack []string, needle string) bool {
+	return sliceIndex(haystack, needle) >= 0
+}
+
+// getPypiVersions returns the Extractions of a Python package, one per
+// version for its distribution file best matching the Source
+func getPypiVersions(client nexusresource.NexusClient, source models.Source) (Extractions, error) {
+	l := utils.NewLogger(source.Debug)
+	l.LogSimpleMessage("In getPypiVersions reading index for '%s'", source.Pypi.Package)
+
+	files, err := GetPypiFiles(client, source.Repository, source.Pypi)
+	if err != nil {
+		return nil, fmt.Errorf("reading pypi index: %s", err)
+	}
+
+	best := map[string]Extraction{}
+	scores := map[string]int{}
+	for _, file := range files {
+		extraction, ok := ExtractPypi(file.Path, source.Pypi)
+		if !ok {
+			continue
+		}
+
+		distribution, _ := ParsePypiFilename(file.Filename)
+		score := pypiFileScore(distribution, source.Pypi)
+		if score > scores[extraction.VersionNumber] {
+			best[extraction.VersionNumber] = extraction
+			scores[extraction.VersionNumber] = score
+		}
+	}
+
+	var extractions = make(Extractions, 0, len(best))
+	for _, extraction := range best {
+		extractions = append(extractions, extraction)
+	}
+
+	l.LogSimpleMessage("In getPypiVersions extracted '%d' versions from the index", len(extractions))
+
+	return extractions, nil
+}
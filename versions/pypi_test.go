@@ -0,0 +1,54 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("PyPI", func() {
+	It("normalizes package names", func() {
+		Ω(versions.NormalizePypiName("Friendly_Bard..Tools")).Should(Equal("friendly-bard-tools"))
+	})
+
+	It("parses wheel filenames", func() {
+		distribution, ok := versions.ParsePypiFilename("friendly_bard-1.2.0-1-cp311-cp311-manylinux1_x86_64.manylinux2010_x86_64.whl")
+		Ω(ok).Should(BeTrue())
+		Ω(distribution).Should(Equal(versions.PypiDistribution{
+			Name:          "friendly_bard",
+			VersionNumber: "1.2.0",
+			Wheel:         true,
+			PythonTags:    []string{"cp311"},
+			Platforms:     []string{"manylinux1_x86_64", "manylinux2010_x86_64"},
+		}))
+	})
+
+	It("parses sdist filenames", func() {
+		distribution, ok := versions.ParsePypiFilename("friendly-bard-1.2.0rc1.tar.gz")
+		Ω(ok).Should(BeTrue())
+		Ω(distribution.Name).Should(Equal("friendly-bard"))
+		Ω(distribution.VersionNumber).Should(Equal("1.2.0rc1"))
+		Ω(distribution.Wheel).Should(BeFalse())
+	})
+
+	It("parses the links of a simple index page", func() {
+		files := versions.ParsePypiIndex(`<html><body>
+<a href="../../packages/friendly-bard/1.2.0/friendly_bard-1.2.0-py3-none-any.whl#sha256=abc123" data-requires-python="&gt;=3.8">friendly_bard-1.2.0-py3-none-any.whl</a><br/>
+<a href="http://nexus-url.com/repository/pypi-hosted/packages/friendly-bard/1.2.0/friendly-bard-1.2.0.tar.gz#sha256=def456">friendly-bard-1.2.0.tar.gz</a><br/>
+</body></html>`, "pypi-hosted", "simple/friendly-bard/")
+
+		Ω(files).Should(Equal([]versions.PypiFile{
+			{Path: "packages/friendly-bard/1.2.0/friendly_bard-1.2.0-py3-none-any.whl", Filename: "friendly_bard-1.2.0-py3-none-any.whl", Sha256: "abc123"},
+			{Path: "packages/friendly-bard/1.2.0/friendly-bard-1.2.0.tar.gz", Filename: "friendly-bard-1.2.0.tar.gz", Sha256: "def456"},
+		}))
+	})
+
+	It("extracts the PEP 440 version of a distribution file", func() {
+		result, ok := versions.ExtractPypi("packages/friendly-bard/1.2.0.post1/friendly_bard-1.2.0.post1-py3-none-any.whl", models.PypiSource{Package: "Friendly.Bard"})
+		Ω(ok).Should(BeTrue())
+		Ω(result.VersionNumber).Should(Equal("1.2.0.post1"))
+		Ω(result.Comparable).Should(BeAssignableToTypeOf(versions.Pep440Version{}))
+	})
+})
@@ -0,0 +1,38 @@
+package versions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+)
+
+// FindAsset returns the asset at a path of a raw repository
+func FindAsset(client nexusresource.NexusClient, repositoryName string, path string) (models.RepositoryItemAsset, error) {
+	items, err := client.SearchComponents(repositoryName, map[string]string{"name": path})
+	if err != nil {
+		return models.RepositoryItemAsset{}, err
+	}
+
+	for _, item := range items {
+		if item.Name == path && len(item.Assets) > 0 {
+			return item.Assets[0], nil
+		}
+	}
+
+	return models.RepositoryItemAsset{}, fmt.Errorf("asset '%s' not found in repository '%s'", path, repositoryName)
+}
+
+// WithContent returns the Version with the sha256 or the upload time of its
+// asset, as configured by the track_redeploys of the Source
+func WithContent(source models.Source, version models.Version, sha256 string, uploadTime time.Time) models.Version {
+	switch source.TrackRedeploys {
+	case models.TrackRedeploysSha256:
+		version.Sha256 = sha256
+	case models.TrackRedeploysLastModified:
+		version.LastModified = uploadTime.UTC().Format(time.RFC3339Nano)
+	}
+
+	return version
+}
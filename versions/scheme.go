@@ -0,0 +1,200 @@
+package versions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cppforlife/go-semi-semantic/version"
+	"github.com/trecnoc/nexus-resource/models"
+)
+
+// Comparable is a version parsed by a version scheme, ordered against the
+// other versions parsed by the same scheme
+type Comparable interface {
+	// Compare returns -1, 0 or 1 when the version is lower, equal or greater
+	// than the other version
+	Compare(other Comparable) int
+}
+
+// Scheme parses version numbers into Comparable versions
+type Scheme interface {
+	Parse(versionNumber string) (Comparable, error)
+}
+
+// SchemeFunc adapts a parsing function to the Scheme interface
+type SchemeFunc func(versionNumber string) (Comparable, error)
+
+// Parse the version number with the function
+func (f SchemeFunc) Parse(versionNumber string) (Comparable, error) {
+	return f(versionNumber)
+}
+
+// NewScheme returns the Scheme of the version_scheme of the provided Source,
+// false is returned for the default semi-semantic versions
+func NewScheme(source models.Source) (Scheme, bool) {
+	switch source.VersionScheme {
+	case models.VersionSchemeSemver:
+		return SchemeFunc(ParseSemver), true
+	case models.VersionSchemeCalver:
+		return SchemeFunc(ParseCalver), true
+	case models.VersionSchemeInteger:
+		return SchemeFunc(ParseInteger), true
+	case models.VersionSchemeTimestamp:
+		return SchemeFunc(func(versionNumber string) (Comparable, error) {
+			return ParseTimestamp(versionNumber, source.VersionLayout)
+		}), true
+	case models.VersionSchemeLexical:
+		return SchemeFunc(func(versionNumber string) (Comparable, error) {
+			return LexicalVersion(versionNumber), nil
+		}), true
+	}
+
+	return nil, false
+}
+
+// ExtractScheme a version from a path with the regexp of the provided Source,
+// stripped of the version_prefix and parsed by the version scheme. ErrNoMatch
+// is returned when the path doesn't match and an error when the version isn't
+// valid for the scheme
+func ExtractScheme(path string, source models.Source) (Extraction, error) {
+	if source.VersionScheme == "" && source.VersionPrefix == "" {
+		extraction, err := Extract(path, source.Regexp)
+		extraction.SortKeys = source.SortKeys
+		return extraction, err
+	}
+
+	match, captures, err := matchVersion(path, source.Regexp)
+	if err != nil {
+		return Extraction{}, err
+	}
+	versionNumber := strings.TrimPrefix(match, source.VersionPrefix)
+
+	// the semi-semantic version is only kept for display when a scheme parses
+	// the version
+	ver, err := version.NewVersionFromString(versionNumber)
+
+	scheme, ok := NewScheme(source)
+	if !ok {
+		if err != nil {
+			return Extraction{}, fmt.Errorf("'%s' is not a valid version: %s", versionNumber, err)
+		}
+
+		return Extraction{Path: path, Version: ver, VersionNumber: versionNumber, Captures: captures, SortKeys: source.SortKeys}, nil
+	}
+
+	comparable, err := scheme.Parse(versionNumber)
+	if err != nil {
+		return Extraction{}, fmt.Errorf("'%s' is not a valid %s version: %s", versionNumber, source.VersionScheme, err)
+	}
+
+	return Extraction{
+		Path:          path,
+		Version:       ver,
+		VersionNumber: versionNumber,
+		Comparable:    comparable,
+		Captures:      captures,
+		SortKeys:      source.SortKeys,
+	}, nil
+}
+
+// CalverVersion is a calendar version such as 2026.10.17 or 26.04, made of
+// numeric components separated by dots
+type CalverVersion []int
+
+// ParseCalver parses a calendar version with at least two components
+func ParseCalver(versionNumber string) (Comparable, error) {
+	parts := strings.Split(versionNumber, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("calendar version '%s' must have at least two components", versionNumber)
+	}
+
+	calver := make(CalverVersion, 0, len(parts))
+	for _, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || !isDigit(part[0]) {
+			return nil, fmt.Errorf("calendar version '%s' must only have numeric components", versionNumber)
+		}
+		calver = append(calver, number)
+	}
+
+	return calver, nil
+}
+
+// Compare returns -1, 0 or 1 when the version is lower, equal or greater
+// than the other version, a version with more components is greater when the
+// others are equal
+func (v CalverVersion) Compare(comparable Comparable) int {
+	other := comparable.(CalverVersion)
+
+	if c := compareInts(v, other); c != 0 {
+		return c
+	}
+
+	return compareInts([]int{len(v)}, []int{len(other)})
+}
+
+// IntegerVersion is a build number
+type IntegerVersion uint64
+
+// ParseInteger parses a build number
+func ParseInteger(versionNumber string) (Comparable, error) {
+	number, err := strconv.ParseUint(versionNumber, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a build number", versionNumber)
+	}
+
+	return IntegerVersion(number), nil
+}
+
+// Compare returns -1, 0 or 1 when the version is lower, equal or greater
+// than the other version
+func (v IntegerVersion) Compare(comparable Comparable) int {
+	other := comparable.(IntegerVersion)
+
+	if v < other {
+		return -1
+	} else if v > other {
+		return 1
+	}
+
+	return 0
+}
+
+// TimestampVersion is a date stamp
+type TimestampVersion time.Time
+
+// ParseTimestamp parses a date stamp with a Go reference time layout, e.g.
+// 20060102T1504Z
+func ParseTimestamp(versionNumber string, layout string) (Comparable, error) {
+	timestamp, err := time.Parse(layout, versionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return TimestampVersion(timestamp), nil
+}
+
+// Compare returns -1, 0 or 1 when the version is earlier, equal or later
+// than the other version
+func (v TimestampVersion) Compare(comparable Comparable) int {
+	other := time.Time(comparable.(TimestampVersion))
+
+	if time.Time(v).Before(other) {
+		return -1
+	} else if time.Time(v).After(other) {
+		return 1
+	}
+
+	return 0
+}
+
+// LexicalVersion is ordered as a string
+type LexicalVersion string
+
+// Compare returns -1, 0 or 1 when the version sorts before, equal or after
+// the other version
+func (v LexicalVersion) Compare(comparable Comparable) int {
+	return strings.Compare(string(v), string(comparable.(LexicalVersion)))
+}
@@ -0,0 +1,103 @@
+package versions_test
+
+import (
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("Version schemes", func() {
+	var source models.Source
+
+	order := func(paths ...string) []string {
+		var extractions versions.Extractions
+		for _, path := range paths {
+			extraction, err := versions.ExtractScheme(path, source)
+			if err == nil {
+				extractions = append(extractions, extraction)
+			}
+		}
+
+		sort.Sort(extractions)
+
+		var versionNumbers []string
+		for _, extraction := range extractions {
+			versionNumbers = append(versionNumbers, extraction.VersionNumber)
+		}
+		return versionNumbers
+	}
+
+	Context("calver", func() {
+		BeforeEach(func() {
+			source = models.Source{Regexp: "tool-(.*).tgz", VersionScheme: models.VersionSchemeCalver}
+		})
+
+		It("orders calendar versions numerically", func() {
+			Ω(order("tool-2026.10.17.tgz", "tool-2026.9.30.tgz", "tool-2026.10.17.1.tgz", "tool-2025.12.01.tgz")).Should(Equal([]string{
+				"2025.12.01", "2026.9.30", "2026.10.17", "2026.10.17.1",
+			}))
+		})
+
+		It("skips versions which aren't calendar versions", func() {
+			Ω(order("tool-2026.tgz", "tool-2026.10.rc1.tgz", "tool-2026.10.tgz")).Should(Equal([]string{"2026.10"}))
+		})
+	})
+
+	Context("integer", func() {
+		BeforeEach(func() {
+			source = models.Source{Regexp: "app-build-(.*).zip", VersionScheme: models.VersionSchemeInteger}
+		})
+
+		It("orders build numbers numerically", func() {
+			Ω(order("app-build-1042.zip", "app-build-999.zip", "app-build-10000.zip", "app-build-latest.zip")).Should(Equal([]string{
+				"999", "1042", "10000",
+			}))
+		})
+	})
+
+	Context("timestamp", func() {
+		BeforeEach(func() {
+			source = models.Source{
+				Regexp:        "dump-(.*).sql.gz",
+				VersionScheme: models.VersionSchemeTimestamp,
+				VersionLayout: "20060102T1504Z",
+			}
+		})
+
+		It("orders date stamps chronologically", func() {
+			Ω(order("dump-20261017T0300Z.sql.gz", "dump-20261016T2300Z.sql.gz", "dump-20261017T0900Z.sql.gz", "dump-2026-10-18.sql.gz")).Should(Equal([]string{
+				"20261016T2300Z", "20261017T0300Z", "20261017T0900Z",
+			}))
+		})
+	})
+
+	Context("lexical", func() {
+		BeforeEach(func() {
+			source = models.Source{Regexp: "release-(.*).tgz", VersionScheme: models.VersionSchemeLexical}
+		})
+
+		It("orders versions as strings", func() {
+			Ω(order("release-b.tgz", "release-a10.tgz", "release-a9.tgz")).Should(Equal([]string{"a10", "a9", "b"}))
+		})
+	})
+
+	Context("with a version prefix", func() {
+		BeforeEach(func() {
+			source = models.Source{Regexp: "app-(.*).tgz", VersionScheme: models.VersionSchemeSemver, VersionPrefix: "v"}
+		})
+
+		It("strips the prefix before parsing the version", func() {
+			Ω(order("app-v1.10.0.tgz", "app-v1.9.0.tgz", "app-1.11.0.tgz")).Should(Equal([]string{"1.9.0", "1.10.0", "1.11.0"}))
+		})
+
+		It("strips the prefix of semi-semantic versions", func() {
+			source.VersionScheme = ""
+
+			Ω(order("app-v1.10.tgz", "app-v1.9.tgz")).Should(Equal([]string{"1.9", "1.10"}))
+		})
+	})
+})
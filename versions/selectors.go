@@ -0,0 +1,60 @@
+package versions
+
+import (
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+// ExtractSelectors extracts a version from a path with the first selector of
+// the provided Source whose regexp matches it, ErrNoMatch is returned when
+// none does
+func ExtractSelectors(path string, source models.Source) (Extraction, error) {
+	err := ErrNoMatch
+	for _, selector := range source.SelectorList() {
+		var extraction Extraction
+		extraction, err = ExtractScheme(path, source.WithSelector(selector))
+		if err != ErrNoMatch {
+			return extraction, err
+		}
+	}
+
+	return Extraction{}, err
+}
+
+// getSelectorVersions merges the versions of the selectors of a raw Source,
+// which are queried concurrently. A version number found by several selectors
+// is kept from the first one
+func getSelectorVersions(client nexusresource.NexusClient, source models.Source) (Extractions, []skippedPath, error) {
+	l := utils.NewLogger(source.Debug)
+
+	selected := make([]Extractions, len(source.Selectors))
+	selectedSkipped := make([][]skippedPath, len(source.Selectors))
+	err := utils.ForEachConcurrently(len(source.Selectors), func(i int) error {
+		var err error
+		selected[i], selectedSkipped[i], err = getRawVersions(client, source.WithSelector(source.Selectors[i]))
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var extractions Extractions
+	var skipped []skippedPath
+	seen := map[string]bool{}
+	for i, selector := range source.Selectors {
+		l.LogSimpleMessage("In getSelectorVersions group '%s' and regexp '%s' have '%d' versions", selector.Group, selector.Regexp, len(selected[i]))
+
+		for _, extraction := range selected[i] {
+			if seen[extraction.VersionNumber] {
+				continue
+			}
+
+			seen[extraction.VersionNumber] = true
+			extractions = append(extractions, extraction)
+		}
+		skipped = append(skipped, selectedSkipped[i]...)
+	}
+
+	return extractions, skipped, nil
+}
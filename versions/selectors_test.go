@@ -0,0 +1,45 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("ExtractSelectors", func() {
+	var source models.Source
+
+	BeforeEach(func() {
+		source = models.Source{
+			Selectors: []models.Selector{
+				{Group: "/legacy/app", Regexp: "legacy/app/app_(.*).tar.gz"},
+				{Group: "/app/releases", Regexp: "app/releases/app-(.*).tgz"},
+			},
+		}
+	})
+
+	It("extracts the version with the selector matching the path", func() {
+		extraction, err := versions.ExtractSelectors("app/releases/app-2.1.0.tgz", source)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(extraction.VersionNumber).Should(Equal("2.1.0"))
+
+		extraction, err = versions.ExtractSelectors("legacy/app/app_1.0.0.tar.gz", source)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(extraction.VersionNumber).Should(Equal("1.0.0"))
+	})
+
+	It("returns ErrNoMatch when no selector matches the path", func() {
+		_, err := versions.ExtractSelectors("app/snapshots/app-2.2.0.tgz", source)
+		Ω(err).Should(Equal(versions.ErrNoMatch))
+	})
+
+	It("uses the group and regexp without selectors", func() {
+		source = models.Source{Regexp: "files/abc-(.*).tgz"}
+
+		extraction, err := versions.ExtractSelectors("files/abc-1.2.tgz", source)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(extraction.VersionNumber).Should(Equal("1.2"))
+	})
+})
@@ -0,0 +1,75 @@
+package versions
+
+import (
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/trecnoc/nexus-resource/models"
+)
+
+// SemverVersion is a strict SemVer 2.0 version, ordered by semver precedence
+type SemverVersion struct {
+	*semver.Version
+}
+
+// ParseSemver parses a strict semantic version
+func ParseSemver(versionNumber string) (Comparable, error) {
+	ver, err := semver.StrictNewVersion(versionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return SemverVersion{ver}, nil
+}
+
+// Compare returns -1, 0 or 1 when the version is lower, equal or greater
+// than the other version
+func (v SemverVersion) Compare(comparable Comparable) int {
+	return v.Version.Compare(comparable.(SemverVersion).Version)
+}
+
+// FilterPreRelease returns the Extractions whose pre-release is allowed by the
+// pre_release option of the provided Source, releases are always kept
+func FilterPreRelease(extractions Extractions, source models.Source) Extractions {
+	if source.PreRelease == "" || source.PreRelease == models.PreReleaseInclude {
+		return extractions
+	}
+
+	filtered := make(Extractions, 0, len(extractions))
+	for _, extraction := range extractions {
+		ver, ok := extraction.Comparable.(SemverVersion)
+		if !ok || ver.Prerelease() == "" {
+			filtered = append(filtered, extraction)
+			continue
+		}
+
+		if source.PreRelease == models.PreReleaseOnly && containsFold(source.PreReleaseIdentifiers, preReleaseLabel(ver.Prerelease())) {
+			filtered = append(filtered, extraction)
+		}
+	}
+
+	return filtered
+}
+
+// preReleaseLabel returns the leading letters of the first identifier of a
+// pre-release, e.g. rc for rc.1 or rc1
+func preReleaseLabel(preRelease string) string {
+	identifier := strings.SplitN(preRelease, ".", 2)[0]
+
+	end := 0
+	for end < len(identifier) && isLetter(identifier[end]) {
+		end++
+	}
+
+	return identifier[:end]
+}
+
+func containsFold(haystack []string, needle string) bool {
+	for _, element := range haystack {
+		if strings.EqualFold(element, needle) {
+			return true
+		}
+	}
+
+	return false
+}
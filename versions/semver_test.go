@@ -0,0 +1,108 @@
+package versions_test
+
+import (
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("ExtractScheme", func() {
+	var source models.Source
+
+	BeforeEach(func() {
+		source = models.Source{Regexp: "app-(.*).tgz"}
+	})
+
+	Context("without a version scheme", func() {
+		It("extracts a semi-semantic version", func() {
+			extraction, err := versions.ExtractScheme("app-1.2.tgz", source)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(extraction.VersionNumber).Should(Equal("1.2"))
+			Ω(extraction.Comparable).Should(BeNil())
+		})
+	})
+
+	Context("with the semver version scheme", func() {
+		BeforeEach(func() {
+			source.VersionScheme = models.VersionSchemeSemver
+		})
+
+		It("extracts a strict semantic version", func() {
+			extraction, err := versions.ExtractScheme("app-1.2.3-rc.1+build.5.tgz", source)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(extraction.Path).Should(Equal("app-1.2.3-rc.1+build.5.tgz"))
+			Ω(extraction.VersionNumber).Should(Equal("1.2.3-rc.1+build.5"))
+			Ω(extraction.Comparable.(versions.SemverVersion).Prerelease()).Should(Equal("rc.1"))
+		})
+
+		It("skips versions which aren't strict semantic versions", func() {
+			for _, path := range []string{"app-1.2.tgz", "app-v1.2.3.tgz", "app-01.2.3.tgz", "app-latest.tgz"} {
+				_, err := versions.ExtractScheme(path, source)
+				Ω(err).Should(MatchError(ContainSubstring("is not a valid semver version")), path)
+			}
+		})
+
+		It("orders versions by semver precedence", func() {
+			var extractions versions.Extractions
+			for _, number := range []string{"1.0.0", "1.0.0-rc.1", "1.0.0-beta.11", "1.0.0-beta.2", "1.0.0-alpha", "1.0.0-alpha.1", "0.9.0"} {
+				extraction, err := versions.ExtractScheme("app-"+number+".tgz", source)
+				Ω(err).ShouldNot(HaveOccurred())
+				extractions = append(extractions, extraction)
+			}
+
+			sort.Sort(extractions)
+
+			var versionNumbers []string
+			for _, extraction := range extractions {
+				versionNumbers = append(versionNumbers, extraction.VersionNumber)
+			}
+			Ω(versionNumbers).Should(Equal([]string{"0.9.0", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0"}))
+		})
+	})
+})
+
+var _ = Describe("FilterPreRelease", func() {
+	var source models.Source
+	var extractions versions.Extractions
+
+	BeforeEach(func() {
+		source = models.Source{Regexp: "app-(.*)", VersionScheme: models.VersionSchemeSemver}
+
+		extractions = nil
+		for _, number := range []string{"1.0.0", "1.1.0-alpha.1", "1.1.0-beta.1", "1.1.0-rc.1", "1.1.0-RC2", "1.1.0"} {
+			extraction, err := versions.ExtractScheme("app-"+number, source)
+			Ω(err).ShouldNot(HaveOccurred())
+			extractions = append(extractions, extraction)
+		}
+	})
+
+	filter := func() []string {
+		var versionNumbers []string
+		for _, extraction := range versions.FilterPreRelease(extractions, source) {
+			versionNumbers = append(versionNumbers, extraction.VersionNumber)
+		}
+		return versionNumbers
+	}
+
+	It("includes pre-releases by default", func() {
+		Ω(filter()).Should(HaveLen(6))
+
+		source.PreRelease = models.PreReleaseInclude
+		Ω(filter()).Should(HaveLen(6))
+	})
+
+	It("excludes pre-releases", func() {
+		source.PreRelease = models.PreReleaseExclude
+		Ω(filter()).Should(Equal([]string{"1.0.0", "1.1.0"}))
+	})
+
+	It("keeps only the pre-releases with the listed identifiers", func() {
+		source.PreRelease = models.PreReleaseOnly
+		source.PreReleaseIdentifiers = []string{"rc"}
+		Ω(filter()).Should(Equal([]string{"1.0.0", "1.1.0-rc.1", "1.1.0-RC2", "1.1.0"}))
+	})
+})
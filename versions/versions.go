@@ -1,8 +1,16 @@
 package versions
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cppforlife/go-semi-semantic/version"
 	"github.com/trecnoc/nexus-resource"
@@ -35,14 +43,45 @@ func MatchUnanchored(paths []string, pattern string) ([]string, error) {
 	return matched, nil
 }
 
-// Extract an version from a path with a provided pattern
-func Extract(path string, pattern string) (Extraction, bool) {
-	compiled := regexp.MustCompile(pattern)
+// ErrNoMatch is returned when a path isn't matched by the pattern
+var ErrNoMatch = errors.New("path does not match the regexp")
+
+// Extract an version from a path with a provided pattern, ErrNoMatch is
+// returned when the path doesn't match and an error when the version isn't valid
+func Extract(path string, pattern string) (Extraction, error) {
+	match, captures, err := matchVersion(path, pattern)
+	if err != nil {
+		return Extraction{}, err
+	}
+
+	ver, err := version.NewVersionFromString(match)
+	if err != nil {
+		return Extraction{}, fmt.Errorf("'%s' is not a valid version: %s", match, err)
+	}
+
+	extraction := Extraction{
+		Path:          path,
+		Version:       ver,
+		VersionNumber: match,
+		Captures:      captures,
+	}
+
+	return extraction, nil
+}
+
+// matchVersion returns the version captured in a path by a provided pattern,
+// the group named version or else the first group, along with the values of
+// all the named groups
+func matchVersion(path string, pattern string) (string, map[string]string, error) {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", nil, err
+	}
 	matches := compiled.FindStringSubmatch(path)
 
 	var match string
 	if len(matches) < 2 { // whole string and match
-		return Extraction{}, false
+		return "", nil, ErrNoMatch
 	} else if len(matches) == 2 {
 		match = matches[1]
 	} else if len(matches) > 2 { // many matches
@@ -56,18 +95,35 @@ func Extract(path string, pattern string) (Extraction, bool) {
 		}
 	}
 
-	ver, err := version.NewVersionFromString(match)
-	if err != nil {
-		panic("version number was not valid: " + err.Error())
+	var captures map[string]string
+	for i, name := range compiled.SubexpNames() {
+		if name == "" {
+			continue
+		}
+
+		if captures == nil {
+			captures = map[string]string{}
+		}
+		captures[name] = matches[i]
 	}
 
-	extraction := Extraction{
-		Path:          path,
-		Version:       ver,
-		VersionNumber: match,
+	return match, captures, nil
+}
+
+// repositoryPath resolves a link found in an index document of the repository,
+// relative to the path of that document, to the path of the linked file
+func repositoryPath(link *url.URL, repositoryName string, basePath string) (string, bool) {
+	if !link.IsAbs() && !strings.HasPrefix(link.Path, "/") {
+		return path.Join(basePath, link.Path), true
+	}
+
+	prefix := "/repository/" + repositoryName + "/"
+	index := strings.Index(link.Path, prefix)
+	if index < 0 {
+		return "", false
 	}
 
-	return extraction, true
+	return link.Path[index+len(prefix):], true
 }
 
 func sliceIndex(haystack []string, needle string) int {
@@ -88,7 +144,7 @@ func (e Extractions) Len() int {
 }
 
 func (e Extractions) Less(i int, j int) bool {
-	return e[i].Version.IsLt(e[j].Version)
+	return e[i].Compare(e[j]) < 0
 }
 
 func (e Extractions) Swap(i int, j int) {
@@ -105,34 +161,374 @@ type Extraction struct {
 
 	// the raw version match
 	VersionNumber string
+
+	// version parsed by the version scheme of the format or of the Source, the
+	// semi-semantic Version is compared when it isn't set
+	Comparable Comparable
+
+	// values of the named groups of the regexp
+	Captures map[string]string
+
+	// named groups compared in order, the version being the group named
+	// version, before the version breaks the ties
+	SortKeys []string
+
+	// upload time and checksum of the asset, set when ordering by last
+	// modification or tracking redeploys
+	LastModified time.Time
+	Sha256       string
+}
+
+// Compare the Extraction to another, returns -1, 0 or 1 when it is lower,
+// equal or greater. A named group missing on either side, e.g. for a version
+// parsed from its version number, is skipped
+func (e Extraction) Compare(other Extraction) int {
+	sortKeys := e.SortKeys
+	if len(sortKeys) == 0 {
+		sortKeys = other.SortKeys
+	}
+
+	for _, key := range sortKeys {
+		var c int
+		if key == "version" {
+			c = e.compareVersion(other)
+		} else {
+			value, ok := e.Captures[key]
+			otherValue, otherOk := other.Captures[key]
+			if !ok || !otherOk {
+				continue
+			}
+			c = compareCapture(value, otherValue)
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return e.compareVersion(other)
+}
+
+func (e Extraction) compareVersion(other Extraction) int {
+	if e.Comparable != nil && other.Comparable != nil && reflect.TypeOf(e.Comparable) == reflect.TypeOf(other.Comparable) {
+		return e.Comparable.Compare(other.Comparable)
+	}
+
+	return e.Version.Compare(other.Version)
+}
+
+// compareCapture compares the values of a named group, numerically when both
+// are numbers
+func compareCapture(value string, other string) int {
+	x, errX := strconv.ParseUint(value, 10, 64)
+	y, errY := strconv.ParseUint(other, 10, 64)
+	if errX == nil && errY == nil {
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+		return 0
+	}
+
+	return strings.Compare(value, other)
+}
+
+// ExtractVersion from a path according to the format of the provided Source
+func ExtractVersion(path string, source models.Source) (Extraction, bool) {
+	switch source.Format {
+	case models.FormatMaven2:
+		return ExtractMaven(path, source.Maven)
+	case models.FormatNpm:
+		return ExtractNpm(path, source.Npm)
+	case models.FormatPypi:
+		return ExtractPypi(path, source.Pypi)
+	case models.FormatHelm:
+		return ExtractHelm(path, source.Helm)
+	case models.FormatDocker:
+		extraction, err := ExtractDocker(path, source)
+		return extraction, err == nil
+	case models.FormatNuGet:
+		return ExtractNuGet(path, source.NuGet)
+	case models.FormatGo:
+		return ExtractGo(path, source.Go)
+	case models.FormatApt:
+		return ExtractApt(path, source.Apt)
+	case models.FormatYum:
+		return ExtractYum(path, source.Yum)
+	default:
+		extraction, err := ExtractSelectors(path, source)
+		return extraction, err == nil
+	}
+}
+
+// ParseVersion returns an Extraction without path for a version number,
+// ordered as the versions of the format and version scheme of the Source
+func ParseVersion(versionNumber string, source models.Source) (Extraction, bool) {
+	var comparable Comparable
+	var err error
+
+	switch source.Format {
+	case models.FormatPypi:
+		parsed, parseErr := NewPep440Version(versionNumber)
+		comparable, err = parsed, parseErr
+	case models.FormatNuGet:
+		parsed, parseErr := NewNuGetVersion(versionNumber)
+		comparable, err = parsed, parseErr
+	case models.FormatApt:
+		parsed, parseErr := NewDpkgVersion(versionNumber)
+		comparable, err = parsed, parseErr
+	case models.FormatYum:
+		parsed, parseErr := NewRpmVersion(versionNumber)
+		comparable, err = parsed, parseErr
+	case models.FormatGo:
+		comparable = GoModuleVersion(versionNumber)
+	case models.FormatMaven2:
+		// Ordered as the listed artifacts, a snapshot by its timestamp and build
+		baseVersion := versionNumber
+		if source.Maven.IsSnapshot() {
+			baseVersion = source.Maven.Version
+		}
+		extraction, ok := ExtractMaven(MavenFilePath(source.Maven, baseVersion, versionNumber), source.Maven)
+		extraction.Path = ""
+		return extraction, ok
+	case models.FormatHelm:
+		extraction, ok := ExtractHelm(HelmChartPath(source.Helm, versionNumber), source.Helm)
+		extraction.Path = ""
+		return extraction, ok
+	case models.FormatNpm:
+	default:
+		versionNumber = strings.TrimPrefix(versionNumber, source.VersionPrefix)
+		if scheme, ok := NewScheme(source); ok {
+			comparable, err = scheme.Parse(versionNumber)
+		}
+	}
+	if err != nil {
+		return Extraction{}, false
+	}
+
+	ver, err := version.NewVersionFromString(versionNumber)
+	if err != nil && comparable == nil {
+		return Extraction{}, false
+	}
+
+	return Extraction{
+		Version:       ver,
+		VersionNumber: versionNumber,
+		Comparable:    comparable,
+	}, true
 }
 
 // GetRepositoryItemVersions returns the Extractions for a provided Source
-func GetRepositoryItemVersions(client nexusresource.NexusClient, source models.Source) Extractions {
+func GetRepositoryItemVersions(client nexusresource.NexusClient, source models.Source) (Extractions, error) {
 	l := utils.NewLogger(source.Debug)
 	l.LogSimpleMessage("In GetRepositoryItemVersions")
-	paths, err := client.ListFiles(source.Repository, source.Group)
+
+	var extractions Extractions
+	var skipped []skippedPath
+	var err error
+	switch source.Format {
+	case models.FormatMaven2:
+		extractions, err = ListMavenVersions(client, source)
+	case models.FormatNpm:
+		extractions, err = getNpmVersions(client, source)
+	case models.FormatPypi:
+		extractions, err = getPypiVersions(client, source)
+	case models.FormatHelm:
+		extractions, err = getHelmVersions(client, source)
+	case models.FormatDocker:
+		extractions, skipped, err = getDockerVersions(client, source)
+	case models.FormatNuGet:
+		extractions, err = getNuGetVersions(client, source)
+	case models.FormatGo:
+		extractions, err = getGoVersions(client, source)
+	case models.FormatApt:
+		extractions, err = getAptVersions(client, source)
+	case models.FormatYum:
+		extractions, err = getYumVersions(client, source)
+	default:
+		extractions, skipped, err = getRawSourceVersions(client, source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(source.IgnoreRegexps) > 0 || len(source.IgnoreGlobs) > 0 {
+		extractions, err = FilterIgnored(extractions, source, l)
+		if err != nil {
+			return nil, err
+		}
+		skipped, err = filterIgnoredSkipped(skipped, source)
+		if err != nil {
+			return nil, err
+		}
+		l.LogSimpleMessage("In GetRepositoryItemVersions '%d' versions aren't ignored", len(extractions))
+	}
+
+	err = reportSkipped(source, skipped)
 	if err != nil {
-		utils.Fatal("listing files", err)
+		return nil, err
+	}
+
+	if source.MinAge != "" || source.MaxAge != "" || source.RequireChecksum {
+		extractions, err = FilterAge(extractions, source, time.Now(), l)
+		if err != nil {
+			return nil, err
+		}
+		l.LogSimpleMessage("In GetRepositoryItemVersions '%d' versions are settled", len(extractions))
+	}
+
+	if source.VersionConstraint != "" {
+		extractions, err = FilterConstraint(extractions, source.VersionConstraint, l)
+		if err != nil {
+			return nil, err
+		}
+		l.LogSimpleMessage("In GetRepositoryItemVersions '%d' versions satisfy the constraint '%s'", len(extractions), source.VersionConstraint)
+	}
+
+	if source.PreRelease != "" {
+		extractions = FilterPreRelease(extractions, source)
+		l.LogSimpleMessage("In GetRepositoryItemVersions '%d' versions match pre_release '%s'", len(extractions), source.PreRelease)
+	}
+
+	if source.OrderBy == models.OrderByLastModified {
+		sort.Sort(byLastModified{extractions})
+	} else {
+		sort.Sort(extractions)
+	}
+	l.LogSimpleMessage("In GetRepositoryItemVersions extracted '%d' versions", len(extractions))
+
+	return extractions, nil
+}
+
+// getRawSourceVersions returns the versions of the selectors of a raw Source,
+// or of its group and regexp
+func getRawSourceVersions(client nexusresource.NexusClient, source models.Source) (Extractions, []skippedPath, error) {
+	if len(source.Selectors) > 0 {
+		return getSelectorVersions(client, source)
+	}
+
+	return getRawVersions(client, source)
+}
+
+func getRawVersions(client nexusresource.NexusClient, source models.Source) (Extractions, []skippedPath, error) {
+	l := utils.NewLogger(source.Debug)
+	if source.UsesAssets() {
+		return getRawAssetVersions(client, source)
+	}
+
+	paths, err := listGroupFiles(client, source)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing files: %s", err)
 	}
 
 	matchingPaths, err := Match(paths, source.Regexp)
 	if err != nil {
-		utils.Fatal("finding matches", err)
+		return nil, nil, fmt.Errorf("finding matches: %s", err)
 	}
-	l.LogSimpleMessage("In GetRepositoryItemVersions found '%d' matching paths to the regex", len(matchingPaths))
+	l.LogSimpleMessage("In getRawVersions found '%d' matching paths to the regex", len(matchingPaths))
 
 	var extractions = make(Extractions, 0, len(matchingPaths))
+	var skipped []skippedPath
 	for _, path := range matchingPaths {
-		extraction, ok := Extract(path, source.Regexp)
+		extraction, err := ExtractScheme(path, source)
+		if err != nil {
+			skipped = append(skipped, skippedPath{path, err})
+			continue
+		}
+
+		extractions = append(extractions, extraction)
+	}
+
+	return extractions, skipped, nil
+}
+
+// listGroupFiles lists the files of the group of the Source, the names of the
+// components of the groups matched by a glob pattern
+func listGroupFiles(client nexusresource.NexusClient, source models.Source) ([]string, error) {
+	if !IsGroupPattern(source.Group) {
+		return client.ListFiles(source.Repository, source.Group)
+	}
+
+	items, err := SearchGroup(client, source.Repository, source.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(items))
+	for _, item := range items {
+		paths = append(paths, item.Name)
+	}
+
+	return paths, nil
+}
 
-		if ok {
-			extractions = append(extractions, extraction)
+// getRawAssetVersions searches the assets of the group to extract their
+// versions along with their upload time and checksum
+func getRawAssetVersions(client nexusresource.NexusClient, source models.Source) (Extractions, []skippedPath, error) {
+	l := utils.NewLogger(source.Debug)
+	items, err := SearchGroup(client, source.Repository, source.Group)
+	if err != nil {
+		return nil, nil, fmt.Errorf("searching assets: %s", err)
+	}
+
+	compiled, err := regexp.Compile("^" + source.Regexp + "$")
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding matches: %s", err)
+	}
+
+	var extractions = make(Extractions, 0, len(items))
+	var skipped []skippedPath
+	for _, item := range items {
+		if !compiled.MatchString(item.Name) || len(item.Assets) == 0 {
+			continue
+		}
+
+		extraction, err := ExtractScheme(item.Name, source)
+		if err != nil {
+			skipped = append(skipped, skippedPath{item.Name, err})
+			continue
 		}
+
+		extraction.LastModified = item.Assets[0].UploadTime()
+		extraction.Sha256 = item.Assets[0].Checksum.Sha256
+		extractions = append(extractions, extraction)
 	}
+	l.LogSimpleMessage("In getRawAssetVersions found '%d' matching assets to the regex", len(extractions))
 
-	sort.Sort(extractions)
-	l.LogSimpleMessage("In GetRepositoryItemVersions extracted '%d' versions from the matching paths", len(extractions))
+	return extractions, skipped, nil
+}
+
+// skippedPath is a path matched by the regexp whose version can't be parsed
+type skippedPath struct {
+	path string
+	err  error
+}
+
+// reportSkipped reports the paths whose version can't be parsed on stderr, in
+// strict mode they fail the check instead
+func reportSkipped(source models.Source, skipped []skippedPath) error {
+	for _, s := range skipped {
+		utils.Sayf("skipping '%s': %s\n", s.path, s.err)
+	}
+
+	if source.Strict && len(skipped) > 0 {
+		return fmt.Errorf("extracting versions: %d matching path(s) have a version which can't be parsed", len(skipped))
+	}
+
+	return nil
+}
+
+// byLastModified orders Extractions by their upload time, the version only
+// breaks the ties
+type byLastModified struct {
+	Extractions
+}
+
+func (e byLastModified) Less(i int, j int) bool {
+	if !e.Extractions[i].LastModified.Equal(e.Extractions[j].LastModified) {
+		return e.Extractions[i].LastModified.Before(e.Extractions[j].LastModified)
+	}
 
-	return extractions
+	return e.Extractions[i].Compare(e.Extractions[j]) < 0
 }
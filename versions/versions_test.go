@@ -1,9 +1,12 @@
 package versions_test
 
 import (
+	"sort"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/trecnoc/nexus-resource/models"
 	"github.com/trecnoc/nexus-resource/versions"
 )
 
@@ -101,16 +104,16 @@ var _ = Describe("Match", func() {
 var _ = Describe("Extract", func() {
 	Context("when the path does not contain extractable information", func() {
 		It("doesn't extract it", func() {
-			result, ok := versions.Extract("abc.tgz", "abc-(.*).tgz")
-			Ω(ok).Should(BeFalse())
+			result, err := versions.Extract("abc.tgz", "abc-(.*).tgz")
+			Ω(err).Should(Equal(versions.ErrNoMatch))
 			Ω(result).Should(BeZero())
 		})
 	})
 
 	Context("when the path contains extractable information", func() {
 		It("extracts it", func() {
-			result, ok := versions.Extract("abc-105.tgz", "abc-(.*).tgz")
-			Ω(ok).Should(BeTrue())
+			result, err := versions.Extract("abc-105.tgz", "abc-(.*).tgz")
+			Ω(err).ShouldNot(HaveOccurred())
 
 			Ω(result.Path).Should(Equal("abc-105.tgz"))
 			Ω(result.Version.String()).Should(Equal("105"))
@@ -118,8 +121,8 @@ var _ = Describe("Extract", func() {
 		})
 
 		It("extracts semantic version numbers", func() {
-			result, ok := versions.Extract("abc-1.0.5.tgz", "abc-(.*).tgz")
-			Ω(ok).Should(BeTrue())
+			result, err := versions.Extract("abc-1.0.5.tgz", "abc-(.*).tgz")
+			Ω(err).ShouldNot(HaveOccurred())
 
 			Ω(result.Path).Should(Equal("abc-1.0.5.tgz"))
 			Ω(result.Version.String()).Should(Equal("1.0.5"))
@@ -127,29 +130,101 @@ var _ = Describe("Extract", func() {
 		})
 
 		It("extracts versions with more than 3 segments", func() {
-			result, ok := versions.Extract("abc-1.0.6.1-rc7.tgz", "abc-(.*).tgz")
-			Ω(ok).Should(BeTrue())
+			result, err := versions.Extract("abc-1.0.6.1-rc7.tgz", "abc-(.*).tgz")
+			Ω(err).ShouldNot(HaveOccurred())
 
 			Ω(result.VersionNumber).Should(Equal("1.0.6.1-rc7"))
 			Ω(result.Version.String()).Should(Equal("1.0.6.1-rc7"))
 		})
 
 		It("takes the first match if there are many", func() {
-			result, ok := versions.Extract("abc-1.0.5-def-2.3.4.tgz", "abc-(.*)-def-(.*).tgz")
-			Ω(ok).Should(BeTrue())
+			result, err := versions.Extract("abc-1.0.5-def-2.3.4.tgz", "abc-(.*)-def-(.*).tgz")
+			Ω(err).ShouldNot(HaveOccurred())
 
 			Ω(result.Path).Should(Equal("abc-1.0.5-def-2.3.4.tgz"))
 			Ω(result.Version.String()).Should(Equal("1.0.5"))
 			Ω(result.VersionNumber).Should(Equal("1.0.5"))
 		})
 
+		It("errors when the version isn't valid", func() {
+			_, err := versions.Extract("release-1..2.tgz", "release-(.*).tgz")
+			Ω(err).Should(MatchError(HavePrefix("'1..2' is not a valid version")))
+		})
+
+		It("errors when the pattern isn't a valid regexp", func() {
+			_, err := versions.Extract("abc-1.0.5.tgz", "abc-(.*.tgz")
+			Ω(err).Should(HaveOccurred())
+		})
+
 		It("extracts a named group called 'version' above all others", func() {
-			result, ok := versions.Extract("abc-1.0.5-def-2.3.4.tgz", "abc-(.*)-def-(?P<version>.*).tgz")
-			Ω(ok).Should(BeTrue())
+			result, err := versions.Extract("abc-1.0.5-def-2.3.4.tgz", "abc-(.*)-def-(?P<version>.*).tgz")
+			Ω(err).ShouldNot(HaveOccurred())
 
 			Ω(result.Path).Should(Equal("abc-1.0.5-def-2.3.4.tgz"))
 			Ω(result.Version.String()).Should(Equal("2.3.4"))
 			Ω(result.VersionNumber).Should(Equal("2.3.4"))
 		})
+
+		It("keeps the values of all the named groups", func() {
+			result, err := versions.Extract("app-1.2.0-42-a1b2c3d.zip", `app-(?P<version>[^-]*)-(?P<build>\d+)-(?P<commit>[0-9a-f]+)(\.zip)`)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(result.VersionNumber).Should(Equal("1.2.0"))
+			Ω(result.Captures).Should(Equal(map[string]string{
+				"version": "1.2.0",
+				"build":   "42",
+				"commit":  "a1b2c3d",
+			}))
+		})
+	})
+})
+
+var _ = Describe("Extraction", func() {
+	Context("with sort keys", func() {
+		It("compares the named groups in order", func() {
+			source := models.Source{
+				Regexp:   `app-(?P<version>[^-]*)-(?P<build>\d+).zip`,
+				SortKeys: []string{"version", "build"},
+			}
+
+			var extractions versions.Extractions
+			for _, path := range []string{"app-1.2.0-9.zip", "app-1.10.0-1.zip", "app-1.2.0-10.zip", "app-1.2.0-2.zip"} {
+				extraction, err := versions.ExtractScheme(path, source)
+				Ω(err).ShouldNot(HaveOccurred())
+				extractions = append(extractions, extraction)
+			}
+
+			sort.Sort(extractions)
+
+			var paths []string
+			for _, extraction := range extractions {
+				paths = append(paths, extraction.Path)
+			}
+			Ω(paths).Should(Equal([]string{"app-1.2.0-2.zip", "app-1.2.0-9.zip", "app-1.2.0-10.zip", "app-1.10.0-1.zip"}))
+		})
+
+		It("compares the groups which aren't numbers as strings", func() {
+			source := models.Source{
+				Regexp:   `app-(?P<channel>[a-z]+)-(?P<version>.*).zip`,
+				SortKeys: []string{"channel"},
+			}
+
+			stable, _ := versions.ExtractScheme("app-stable-1.0.0.zip", source)
+			beta, _ := versions.ExtractScheme("app-beta-2.0.0.zip", source)
+			Ω(beta.Compare(stable)).Should(Equal(-1))
+		})
+
+		It("compares the version when a group is missing on either side", func() {
+			source := models.Source{
+				Regexp:   `app-(?P<version>[^-]*)-(?P<build>\d+).zip`,
+				SortKeys: []string{"build"},
+			}
+
+			extraction, _ := versions.ExtractScheme("app-0.1.0-9.zip", source)
+			initialVersion, ok := versions.ParseVersion("9.0.0", source)
+			Ω(ok).Should(BeTrue())
+			Ω(extraction.Compare(initialVersion)).Should(Equal(-1))
+			Ω(initialVersion.Compare(extraction)).Should(Equal(1))
+		})
 	})
 })
@@ -0,0 +1,345 @@
+package versions
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/cppforlife/go-semi-semantic/version"
+	"github.com/trecnoc/nexus-resource"
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/utils"
+)
+
+// RpmVersion struct is an RPM package version, [epoch:]version-release
+type RpmVersion struct {
+	Epoch   int
+	Version string
+	Release string
+}
+
+// NewRpmVersion parses an RPM package version
+func NewRpmVersion(input string) (RpmVersion, error) {
+	var ver RpmVersion
+
+	value := input
+	if index := strings.Index(value, ":"); index >= 0 {
+		epoch, err := strconv.Atoi(value[:index])
+		if err != nil {
+			return ver, fmt.Errorf("'%s' is not a valid RPM version: invalid epoch", input)
+		}
+		ver.Epoch = epoch
+		value = value[index+1:]
+	}
+
+	if index := strings.LastIndex(value, "-"); index >= 0 {
+		ver.Release = value[index+1:]
+		value = value[:index]
+	}
+
+	if value == "" {
+		return ver, fmt.Errorf("'%s' is not a valid RPM version", input)
+	}
+	ver.Version = value
+
+	return ver, nil
+}
+
+// Compare returns -1, 0 or 1 when the version is lower, equal or greater
+// than the other version, following the rpm ordering
+func (v RpmVersion) Compare(comparable Comparable) int {
+	other := comparable.(RpmVersion)
+	if c := compareInts([]int{v.Epoch}, []int{other.Epoch}); c != 0 {
+		return c
+	}
+
+	if c := rpmvercmp(v.Version, other.Version); c != 0 {
+		return c
+	}
+
+	return rpmvercmp(v.Release, other.Release)
+}
+
+// rpmvercmp compares alternating alphabetic and numeric segments, as rpm
+// does, a tilde sorts before anything and a caret after the end of a version
+func rpmvercmp(a string, b string) int {
+	if a == b {
+		return 0
+	}
+
+	at := func(s string, i int) byte {
+		if i < len(s) {
+			return s[i]
+		}
+		return 0
+	}
+	isSeparator := func(c byte) bool {
+		return c != 0 && !isDigit(c) && !isLetter(c) && c != '~' && c != '^'
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for isSeparator(at(a, i)) {
+			i++
+		}
+		for isSeparator(at(b, j)) {
+			j++
+		}
+
+		if at(a, i) == '~' || at(b, j) == '~' {
+			if at(a, i) != '~' {
+				return 1
+			}
+			if at(b, j) != '~' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if at(a, i) == '^' || at(b, j) == '^' {
+			switch {
+			case i >= len(a):
+				return -1
+			case j >= len(b):
+				return 1
+			case a[i] != '^':
+				return 1
+			case b[j] != '^':
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		startA, startB := i, j
+		numeric := isDigit(a[i])
+		segment := isLetter
+		if numeric {
+			segment = isDigit
+		}
+		for i < len(a) && segment(a[i]) {
+			i++
+		}
+		for j < len(b) && segment(b[j]) {
+			j++
+		}
+
+		// Segments of different types, numeric ones are newer
+		if startB == j {
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+
+		x, y := a[startA:i], b[startB:j]
+		if numeric {
+			x = strings.TrimLeft(x, "0")
+			y = strings.TrimLeft(y, "0")
+			if c := compareInts([]int{len(x)}, []int{len(y)}); c != 0 {
+				return c
+			}
+		}
+
+		if c := strings.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i < len(a):
+		return 1
+	}
+
+	return -1
+}
+
+// RpmFilename struct is the information encoded in an RPM filename,
+// <name>-<version>-<release>.<arch>.rpm
+type RpmFilename struct {
+	Name    string
+	Version string
+	Arch    string
+}
+
+// ParseRpmFilename parses an RPM filename
+func ParseRpmFilename(filename string) (RpmFilename, bool) {
+	if !strings.HasSuffix(filename, ".rpm") {
+		return RpmFilename{}, false
+	}
+	base := strings.TrimSuffix(filename, ".rpm")
+
+	archIndex := strings.LastIndex(base, ".")
+	if archIndex < 0 {
+		return RpmFilename{}, false
+	}
+	arch := base[archIndex+1:]
+	base = base[:archIndex]
+
+	releaseIndex := strings.LastIndex(base, "-")
+	if releaseIndex < 0 {
+		return RpmFilename{}, false
+	}
+	versionIndex := strings.LastIndex(base[:releaseIndex], "-")
+	if versionIndex <= 0 {
+		return RpmFilename{}, false
+	}
+
+	return RpmFilename{
+		Name:    base[:versionIndex],
+		Version: base[versionIndex+1:],
+		Arch:    arch,
+	}, true
+}
+
+// GetYumPackages returns the versions of the package listed in the primary
+// metadata of the repodata directory
+func GetYumPackages(client nexusresource.NexusClient, repositoryName string, yum models.YumSource) ([]models.YumPackage, error) {
+	directory := strings.Trim(yum.Directory, "/")
+
+	content, err := client.GetFile(repositoryName, path.Join(directory, "repodata/repomd.xml"))
+	if err != nil {
+		return nil, err
+	}
+
+	var repomd models.YumRepomd
+	err = xml.Unmarshal(content, &repomd)
+	if err != nil {
+		return nil, fmt.Errorf("reading repomd.xml: %s", err)
+	}
+
+	primaryPath := ""
+	for _, data := range repomd.Data {
+		if data.Type == "primary" {
+			primaryPath = path.Join(directory, data.Location.Href)
+		}
+	}
+	if primaryPath == "" {
+		return nil, fmt.Errorf("no primary metadata in repomd.xml")
+	}
+
+	content, err = client.GetFile(repositoryName, primaryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(primaryPath, ".gz") {
+		gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %s", primaryPath, err)
+		}
+		content, err = io.ReadAll(gzipReader)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %s", primaryPath, err)
+		}
+	}
+
+	var primary models.YumPrimary
+	err = xml.Unmarshal(content, &primary)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %s", primaryPath, err)
+	}
+
+	var packages []models.YumPackage
+	for _, yumPackage := range primary.Packages {
+		if yumPackage.Name != yum.Package {
+			continue
+		}
+		if yum.Architecture != "" && yumPackage.Arch != yum.Architecture && yumPackage.Arch != "noarch" {
+			continue
+		}
+
+		yumPackage.Location.Href = path.Join(directory, yumPackage.Location.Href)
+		packages = append(packages, yumPackage)
+	}
+
+	return packages, nil
+}
+
+// ExtractYum a version from the path of an .rpm
+func ExtractYum(rpmPath string, yum models.YumSource) (Extraction, bool) {
+	filename, ok := ParseRpmFilename(path.Base(rpmPath))
+	if !ok || filename.Name != yum.Package {
+		return Extraction{}, false
+	}
+
+	if yum.Architecture != "" && filename.Arch != yum.Architecture && filename.Arch != "noarch" {
+		return Extraction{}, false
+	}
+
+	rpm, err := NewRpmVersion(filename.Version)
+	if err != nil {
+		return Extraction{}, false
+	}
+
+	// Only used for display, the ordering relies on the rpm version
+	ver, _ := version.NewVersionFromString(filename.Version)
+
+	return Extraction{
+		Path:          rpmPath,
+		Version:       ver,
+		VersionNumber: filename.Version,
+		Comparable:    rpm,
+	}, true
+}
+
+// ExtractYumPackage a version from a package of the primary metadata, ordered
+// by the epoch, version and release of the metadata since the epoch isn't part
+// of the filename
+func ExtractYumPackage(yumPackage models.YumPackage, yum models.YumSource) (Extraction, bool) {
+	extraction, ok := ExtractYum(yumPackage.Location.Href, yum)
+	if !ok {
+		return Extraction{}, false
+	}
+
+	rpm := RpmVersion{Version: yumPackage.Version.Version, Release: yumPackage.Version.Release}
+	if yumPackage.Version.Epoch != "" {
+		epoch, err := strconv.Atoi(yumPackage.Version.Epoch)
+		if err != nil {
+			return Extraction{}, false
+		}
+		rpm.Epoch = epoch
+	}
+	extraction.Comparable = rpm
+
+	return extraction, true
+}
+
+// getYumVersions returns the Extractions of an RPM package listed in the
+// primary metadata of the repository
+func getYumVersions(client nexusresource.NexusClient, source models.Source) (Extractions, error) {
+	l := utils.NewLogger(source.Debug)
+	l.LogSimpleMessage("In getYumVersions reading repodata of '%s'", source.Yum.Directory)
+
+	packages, err := GetYumPackages(client, source.Repository, source.Yum)
+	if err != nil {
+		return nil, fmt.Errorf("reading yum repodata: %s", err)
+	}
+
+	var extractions = make(Extractions, 0, len(packages))
+	for _, yumPackage := range packages {
+		extraction, ok := ExtractYumPackage(yumPackage, source.Yum)
+
+		if ok {
+			extractions = append(extractions, extraction)
+		}
+	}
+
+	l.LogSimpleMessage("In getYumVersions extracted '%d' versions from the repodata", len(extractions))
+
+	return extractions, nil
+}
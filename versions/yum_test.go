@@ -0,0 +1,96 @@
+package versions_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/trecnoc/nexus-resource/models"
+	"github.com/trecnoc/nexus-resource/versions"
+)
+
+var _ = Describe("RpmVersion", func() {
+	compare := func(a string, b string) int {
+		x, err := versions.NewRpmVersion(a)
+		Ω(err).ShouldNot(HaveOccurred())
+		y, err := versions.NewRpmVersion(b)
+		Ω(err).ShouldNot(HaveOccurred())
+		return x.Compare(y)
+	}
+
+	It("orders numeric segments numerically", func() {
+		Ω(compare("1.10-1", "1.9-1")).Should(Equal(1))
+		Ω(compare("1.001-1", "1.1-1")).Should(Equal(0))
+	})
+
+	It("orders numeric segments after alphabetic ones", func() {
+		Ω(compare("1.0a-1", "1.01-1")).Should(Equal(-1))
+		Ω(compare("2.0.1-1", "2.0a-1")).Should(Equal(1))
+	})
+
+	It("ignores separators", func() {
+		Ω(compare("1_0-1", "1.0-1")).Should(Equal(0))
+	})
+
+	It("orders a tilde before and a caret after the end", func() {
+		Ω(compare("1.0~rc1-1", "1.0-1")).Should(Equal(-1))
+		Ω(compare("1.0^git1-1", "1.0-1")).Should(Equal(1))
+		Ω(compare("1.0^git1-1", "1.0.1-1")).Should(Equal(-1))
+	})
+
+	It("reports the invalid version", func() {
+		_, err := versions.NewRpmVersion("x:1.0-1")
+		Ω(err).Should(MatchError("'x:1.0-1' is not a valid RPM version: invalid epoch"))
+
+		_, err = versions.NewRpmVersion("1:-1")
+		Ω(err).Should(MatchError("'1:-1' is not a valid RPM version"))
+	})
+
+	It("orders by epoch then release", func() {
+		Ω(compare("1:1.0-1", "2.0-1")).Should(Equal(1))
+		Ω(compare("1.0-2.el9", "1.0-10.el9")).Should(Equal(-1))
+	})
+})
+
+var _ = Describe("ExtractYum", func() {
+	yum := models.YumSource{Package: "billing-agent", Architecture: "x86_64"}
+
+	It("extracts the version of an .rpm", func() {
+		result, ok := versions.ExtractYum("el9/billing-agent-1.2.0-1.el9.x86_64.rpm", yum)
+		Ω(ok).Should(BeTrue())
+		Ω(result.VersionNumber).Should(Equal("1.2.0-1.el9"))
+	})
+
+	It("extracts noarch packages", func() {
+		_, ok := versions.ExtractYum("billing-agent-1.2.0-1.noarch.rpm", yum)
+		Ω(ok).Should(BeTrue())
+	})
+
+	It("doesn't extract other packages or architectures", func() {
+		_, ok := versions.ExtractYum("billing-agent-1.2.0-1.el9.aarch64.rpm", yum)
+		Ω(ok).Should(BeFalse())
+
+		_, ok = versions.ExtractYum("billing-1.2.0-1.el9.x86_64.rpm", yum)
+		Ω(ok).Should(BeFalse())
+	})
+})
+
+var _ = Describe("ExtractYumPackage", func() {
+	yum := models.YumSource{Package: "billing-agent"}
+
+	It("orders the package by the epoch of the metadata", func() {
+		var yumPackage models.YumPackage
+		yumPackage.Name = "billing-agent"
+		yumPackage.Version.Epoch = "1"
+		yumPackage.Version.Version = "0.9.0"
+		yumPackage.Version.Release = "1.el9"
+		yumPackage.Location.Href = "el9/billing-agent-0.9.0-1.el9.x86_64.rpm"
+
+		epoch, ok := versions.ExtractYumPackage(yumPackage, yum)
+		Ω(ok).Should(BeTrue())
+		Ω(epoch.VersionNumber).Should(Equal("0.9.0-1.el9"))
+
+		newer, ok := versions.ExtractYum("el9/billing-agent-1.10.0-1.el9.x86_64.rpm", yum)
+		Ω(ok).Should(BeTrue())
+		Ω(epoch.Compare(newer)).Should(Equal(1))
+	})
+})